@@ -0,0 +1,171 @@
+package ds
+
+// AllPairsShortestPaths holds the shortest path distances between all pairs of
+// vertices of a graph, as computed by FloydWarshall and Johnson. Paths are
+// reconstructed from a predecessor matrix.
+type AllPairsShortestPaths struct {
+	dist          [][]int
+	pred          [][]int
+	negativeCycle []int
+}
+
+// newAllPairsShortestPaths returns the result for v vertices with no paths known.
+func newAllPairsShortestPaths(v int) *AllPairsShortestPaths {
+	apsp := &AllPairsShortestPaths{dist: make([][]int, v), pred: make([][]int, v)}
+
+	for x := 0; x < v; x++ {
+		apsp.dist[x] = make([]int, v)
+		apsp.pred[x] = make([]int, v)
+
+		for y := 0; y < v; y++ {
+			apsp.dist[x][y] = INFINITE_DISTANCE
+			apsp.pred[x][y] = -1
+		}
+	}
+
+	return apsp
+}
+
+// Dist returns the length of the shortest path from x to y, or INFINITE_DISTANCE
+// if there is no such path.
+func (apsp *AllPairsShortestPaths) Dist(x, y int) int {
+	if x < 0 || x >= len(apsp.dist) || y < 0 || y >= len(apsp.dist) {
+		return INFINITE_DISTANCE
+	}
+	return apsp.dist[x][y]
+}
+
+// HasPath returns true if there is a path from x to y.
+func (apsp *AllPairsShortestPaths) HasPath(x, y int) bool {
+	return apsp.Dist(x, y) != INFINITE_DISTANCE
+}
+
+// Path returns the vertices on a shortest path from x to y. If there is no such
+// path, or the graph has a negative cycle, nil is returned.
+func (apsp *AllPairsShortestPaths) Path(x, y int) []int {
+	if !apsp.HasPath(x, y) || apsp.HasNegativeCycle() {
+		return nil
+	}
+
+	path := []int{y}
+
+	for v := y; v != x; {
+		v = apsp.pred[x][v]
+		path = append(path, v)
+	}
+
+	reverseInts(path)
+
+	return path
+}
+
+// HasNegativeCycle returns true if the graph has a negative cycle. In that case
+// the distances are meaningless.
+func (apsp *AllPairsShortestPaths) HasNegativeCycle() bool {
+	return apsp.negativeCycle != nil
+}
+
+// NegativeCycle returns the vertices of a negative cycle in the order of its edges,
+// with the first vertex repeated at the end. If there is no such cycle, nil is returned.
+func (apsp *AllPairsShortestPaths) NegativeCycle() []int {
+	if apsp.negativeCycle == nil {
+		return nil
+	}
+	return append([]int{}, apsp.negativeCycle...)
+}
+
+// FloydWarshall computes the shortest paths between all pairs of vertices using the
+// Floyd-Warshall algorithm in O(V^3) time and O(V^2) space. It is best suited for
+// dense graphs. Negative edge weights are allowed.
+func FloydWarshall(g GraphView) *AllPairsShortestPaths {
+	v := g.V()
+	apsp := newAllPairsShortestPaths(v)
+	dist, pred := apsp.dist, apsp.pred
+
+	for x := 0; x < v; x++ {
+		dist[x][x] = 0
+
+		for it := g.OutEdges(x); it.HasNext(); {
+			if e := nextEdge(it); e.Weight < dist[x][e.To] {
+				dist[x][e.To] = e.Weight
+				pred[x][e.To] = x
+			}
+		}
+	}
+
+	for k := 0; k < v; k++ {
+		for i := 0; i < v; i++ {
+			if dist[i][k] == INFINITE_DISTANCE {
+				continue
+			}
+
+			for j := 0; j < v; j++ {
+				if dist[k][j] != INFINITE_DISTANCE && dist[i][k]+dist[k][j] < dist[i][j] {
+					dist[i][j] = dist[i][k] + dist[k][j]
+					pred[i][j] = pred[k][j]
+				}
+			}
+		}
+
+		// stop as soon as a negative cycle shows up to keep distances from overflowing
+		for i := 0; i < v; i++ {
+			if dist[i][i] < 0 {
+				sp, _ := BellmanFord(g, i)
+				apsp.negativeCycle = sp.NegativeCycle()
+				return apsp
+			}
+		}
+	}
+
+	return apsp
+}
+
+// Johnson computes the shortest paths between all pairs of vertices using Johnson's
+// algorithm in O(VE log V). The edges are reweighted with potentials obtained by
+// BellmanFord so that Dijkstra can be run from every vertex, which makes it faster
+// than FloydWarshall on sparse graphs. Negative edge weights are allowed.
+func Johnson(g GraphView) *AllPairsShortestPaths {
+	apsp := newAllPairsShortestPaths(g.V())
+
+	// a virtual source q connected to every vertex yields the potentials
+	q := g.V()
+	augmented := NewGraph(g.V()+1, true)
+
+	for x := 0; x < g.V(); x++ {
+		for it := g.OutEdges(x); it.HasNext(); {
+			e := nextEdge(it)
+			augmented.AddEdge(x, e.To, e.Weight)
+		}
+		augmented.AddEdge(q, x, 0)
+	}
+
+	potentials, _ := BellmanFord(augmented, q)
+
+	if potentials.HasNegativeCycle() {
+		apsp.negativeCycle = potentials.NegativeCycle()
+		return apsp
+	}
+
+	h := potentials.dist
+	reweighted := NewGraph(g.V(), true)
+
+	for x := 0; x < g.V(); x++ {
+		for it := g.OutEdges(x); it.HasNext(); {
+			e := nextEdge(it)
+			reweighted.AddEdge(x, e.To, e.Weight+h[x]-h[e.To])
+		}
+	}
+
+	for s := 0; s < g.V(); s++ {
+		sp, _ := Dijkstra(reweighted, s)
+
+		for t := 0; t < g.V(); t++ {
+			if sp.HasPathTo(t) {
+				apsp.dist[s][t] = sp.dist[t] - h[s] + h[t]
+				apsp.pred[s][t] = sp.parent[t]
+			}
+		}
+	}
+
+	return apsp
+}
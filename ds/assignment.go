@@ -0,0 +1,325 @@
+package ds
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Assignment is a solution of the assignment problem computed by Hungarian: every
+// row of the cost matrix is assigned to a distinct column or, if there are more rows
+// than columns, every column to a distinct row.
+type Assignment struct {
+	colOf []int
+	rowOf []int
+	cost  int
+}
+
+// Cost returns the total cost of the assignment.
+func (a *Assignment) Cost() int {
+	return a.cost
+}
+
+// Col returns the column assigned to the given row, or -1 if the row is unassigned
+// or out of bounds.
+func (a *Assignment) Col(row int) int {
+	if row < 0 || row >= len(a.colOf) {
+		return -1
+	}
+	return a.colOf[row]
+}
+
+// Row returns the row assigned to the given column, or -1 if the column is
+// unassigned or out of bounds.
+func (a *Assignment) Row(col int) int {
+	if col < 0 || col >= len(a.rowOf) {
+		return -1
+	}
+	return a.rowOf[col]
+}
+
+// Pairs returns the assigned pairs of row and column in increasing row order.
+func (a *Assignment) Pairs() [][2]int {
+	pairs := [][2]int{}
+
+	for row, col := range a.colOf {
+		if col != -1 {
+			pairs = append(pairs, [2]int{row, col})
+		}
+	}
+
+	return pairs
+}
+
+// Hungarian solves the assignment problem for an n x m cost matrix using the
+// Hungarian algorithm with potentials in O(n^2 m) for n <= m. Rectangular problems
+// assign every row if n <= m and every column otherwise. If maximize is true, the
+// total cost is maximized instead of minimized. If the rows of the matrix differ in
+// length or the costs are so large that a sum overflows an int, a non-nil error is
+// returned.
+func Hungarian(costs [][]int, maximize bool) (*Assignment, error) {
+	n, m := len(costs), 0
+	if n > 0 {
+		m = len(costs[0])
+	}
+
+	for i, row := range costs {
+		if len(row) != m {
+			return nil, fmt.Errorf("row %d has %d columns, expected %d", i, len(row), m)
+		}
+	}
+
+	colOf, err := solveAssignment(n, m, maximize, func(i, j int) (int, bool) {
+		return costs[i][j], true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	a := &Assignment{colOf: colOf, rowOf: make([]int, m)}
+
+	for j := range a.rowOf {
+		a.rowOf[j] = -1
+	}
+
+	for i, j := range colOf {
+		if j == -1 {
+			continue
+		}
+
+		a.rowOf[j] = i
+
+		sum, ok := addInts(a.cost, costs[i][j])
+		if !ok {
+			return nil, errors.New("total cost of the assignment overflows int")
+		}
+
+		a.cost = sum
+	}
+
+	return a, nil
+}
+
+// HungarianGraph solves the assignment problem on a bipartite undirected graph whose
+// edge weights are the costs. The vertices in left form one side, e.g. the workers,
+// and all other vertices the other side, e.g. the tasks. Every vertex of the smaller
+// side is matched to a distinct vertex of the other side along an edge, such that
+// the total weight of the matched edges is minimal, or maximal if maximize is true.
+// Of parallel edges the best one is used. It returns the matching and its total
+// weight. If the graph is directed or not bipartite, if left holds an invalid or
+// repeated vertex, if an edge does not connect the two sides, if the smaller side
+// cannot be matched completely, or if the weights are so large that a sum overflows
+// an int, a non-nil error is returned.
+func HungarianGraph(g GraphView, left []int, maximize bool) (*Matching, int, error) {
+	b, err := Bipartite(g)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if !b.IsBipartite() {
+		return nil, 0, fmt.Errorf("graph is not bipartite, it has an odd cycle: %v", b.oddCycle)
+	}
+
+	// index holds the position of every vertex within its side
+	index := make([]int, g.V())
+	onLeft := make([]bool, g.V())
+
+	for i, v := range left {
+		if err := validateViewVertex(g, v); err != nil {
+			return nil, 0, err
+		}
+
+		if onLeft[v] {
+			return nil, 0, fmt.Errorf("vertex %d is listed twice", v)
+		}
+
+		onLeft[v] = true
+		index[v] = i
+	}
+
+	right := []int{}
+
+	for v := 0; v < g.V(); v++ {
+		if !onLeft[v] {
+			index[v] = len(right)
+			right = append(right, v)
+		}
+	}
+
+	weights := map[[2]int]int{}
+
+	for x := 0; x < g.V(); x++ {
+		for it := g.OutEdges(x); it.HasNext(); {
+			e := nextEdge(it)
+
+			if onLeft[x] == onLeft[e.To] {
+				return nil, 0, fmt.Errorf("edge %d - %d does not connect the two sides", x, e.To)
+			}
+
+			if !onLeft[x] {
+				continue
+			}
+
+			pair := [2]int{index[x], index[e.To]}
+
+			if w, ok := weights[pair]; !ok || (maximize && e.Weight > w) || (!maximize && e.Weight < w) {
+				weights[pair] = e.Weight
+			}
+		}
+	}
+
+	colOf, err := solveAssignment(len(left), len(right), maximize, func(i, j int) (int, bool) {
+		w, ok := weights[[2]int{i, j}]
+		return w, ok
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	m := newMatching(g.V())
+	total := 0
+
+	for i, j := range colOf {
+		if j == -1 {
+			continue
+		}
+
+		m.match(left[i], right[j])
+		m.size++
+
+		sum, ok := addInts(total, weights[[2]int{i, j}])
+		if !ok {
+			return nil, 0, errors.New("total weight of the matching overflows int")
+		}
+
+		total = sum
+	}
+
+	return m, total, nil
+}
+
+// solveAssignment returns the column assigned to every row of an n x m assignment
+// problem, or -1 for unassigned rows. cost returns the cost of assigning row i to
+// column j and false if the assignment is not allowed. Problems with more rows than
+// columns are solved transposed. If the costs are so large that a sum overflows an
+// int, a non-nil error is returned.
+func solveAssignment(n, m int, maximize bool, cost func(i, j int) (int, bool)) ([]int, error) {
+	if n > m {
+		rowOf, err := solveAssignment(m, n, maximize, func(i, j int) (int, bool) {
+			return cost(j, i)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		colOf := make([]int, n)
+
+		for i := range colOf {
+			colOf[i] = -1
+		}
+
+		for j, i := range rowOf {
+			colOf[i] = j
+		}
+
+		return colOf, nil
+	}
+
+	overflow := errors.New("costs are too large, a sum overflows int")
+
+	// rows and columns are numbered from 1, column 0 is a virtual column holding the
+	// row that is currently being added
+	u := make([]int, n+1)
+	v := make([]int, m+1)
+	rowOf := make([]int, m+1)
+	way := make([]int, m+1)
+	minv := make([]int, m+1)
+	used := make([]bool, m+1)
+
+	for i := 1; i <= n; i++ {
+		rowOf[0] = i
+		j0 := 0
+
+		for j := range minv {
+			minv[j] = INFINITE_DISTANCE
+			used[j] = false
+		}
+
+		// grow a tree of alternating paths from row i until it reaches a free column
+		for rowOf[j0] != 0 {
+			used[j0] = true
+			i0 := rowOf[j0]
+			delta, j1 := INFINITE_DISTANCE, -1
+
+			for j := 1; j <= m; j++ {
+				if used[j] {
+					continue
+				}
+
+				if c, ok := cost(i0-1, j-1); ok {
+					fits := true
+					if maximize {
+						c, fits = subInts(0, c)
+					}
+
+					reduced, fitsU := subInts(c, u[i0])
+					reduced, fitsV := subInts(reduced, v[j])
+
+					if !fits || !fitsU || !fitsV || reduced == INFINITE_DISTANCE {
+						return nil, overflow
+					}
+
+					if reduced < minv[j] {
+						minv[j] = reduced
+						way[j] = j0
+					}
+				}
+
+				if minv[j] < delta {
+					delta, j1 = minv[j], j
+				}
+			}
+
+			if j1 == -1 {
+				return nil, errors.New("no assignment covers the smaller side completely")
+			}
+
+			for j := 0; j <= m; j++ {
+				if used[j] {
+					uj, fitsU := addInts(u[rowOf[j]], delta)
+					vj, fitsV := subInts(v[j], delta)
+
+					if !fitsU || !fitsV {
+						return nil, overflow
+					}
+
+					u[rowOf[j]], v[j] = uj, vj
+				} else if minv[j] != INFINITE_DISTANCE {
+					minv[j] -= delta
+				}
+			}
+
+			j0 = j1
+		}
+
+		// flip the alternating path ending in the free column j0
+		for j0 != 0 {
+			j1 := way[j0]
+			rowOf[j0] = rowOf[j1]
+			j0 = j1
+		}
+	}
+
+	colOf := make([]int, n)
+
+	for i := range colOf {
+		colOf[i] = -1
+	}
+
+	for j := 1; j <= m; j++ {
+		if rowOf[j] != 0 {
+			colOf[rowOf[j]-1] = j - 1
+		}
+	}
+
+	return colOf, nil
+}
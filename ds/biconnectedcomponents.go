@@ -0,0 +1,215 @@
+package ds
+
+import (
+	"errors"
+	"sort"
+)
+
+// BiconnectedComponents holds the bridges, articulation points and biconnected
+// components of an undirected graph, as computed by HopcroftTarjan. Each edge that
+// is not a self-loop belongs to exactly one biconnected component. Edges are
+// reported with From <= To.
+type BiconnectedComponents struct {
+	bridges      []Edge
+	articulation []bool
+	components   [][]Edge
+	componentOf  map[[2]int]int
+}
+
+// Bridges returns the edges whose removal disconnects the graph.
+func (bc *BiconnectedComponents) Bridges() []Edge {
+	return append([]Edge{}, bc.bridges...)
+}
+
+// IsBridge returns true if the edge between x and y is a bridge.
+func (bc *BiconnectedComponents) IsBridge(x, y int) bool {
+	id := bc.ComponentOf(x, y)
+	return id != -1 && len(bc.components[id]) == 1
+}
+
+// ArticulationPoints returns the vertices whose removal disconnects the graph in
+// increasing order.
+func (bc *BiconnectedComponents) ArticulationPoints() []int {
+	points := []int{}
+
+	for v, ok := range bc.articulation {
+		if ok {
+			points = append(points, v)
+		}
+	}
+
+	return points
+}
+
+// IsArticulationPoint returns true if the given vertex is an articulation point.
+func (bc *BiconnectedComponents) IsArticulationPoint(v int) bool {
+	return v >= 0 && v < len(bc.articulation) && bc.articulation[v]
+}
+
+// Count returns the number of biconnected components.
+func (bc *BiconnectedComponents) Count() int {
+	return len(bc.components)
+}
+
+// Components returns the edges of every biconnected component, indexed by
+// component id.
+func (bc *BiconnectedComponents) Components() [][]Edge {
+	components := make([][]Edge, len(bc.components))
+
+	for i, c := range bc.components {
+		components[i] = append([]Edge{}, c...)
+	}
+
+	return components
+}
+
+// ComponentOf returns the id of the biconnected component containing the edge
+// between x and y, or -1 if there is no such edge.
+func (bc *BiconnectedComponents) ComponentOf(x, y int) int {
+	if x > y {
+		x, y = y, x
+	}
+
+	if id, ok := bc.componentOf[[2]int{x, y}]; ok {
+		return id
+	}
+
+	return -1
+}
+
+// addComponent records the given edges as a new biconnected component.
+func (bc *BiconnectedComponents) addComponent(edges []Edge) {
+	id := len(bc.components)
+	component := make([]Edge, len(edges))
+
+	for i, e := range edges {
+		if e.From > e.To {
+			e.From, e.To = e.To, e.From
+		}
+
+		component[i] = e
+		bc.componentOf[[2]int{e.From, e.To}] = id
+	}
+
+	sort.Slice(component, func(i, j int) bool {
+		if component[i].From != component[j].From {
+			return component[i].From < component[j].From
+		}
+		return component[i].To < component[j].To
+	})
+
+	bc.components = append(bc.components, component)
+
+	if len(component) == 1 {
+		bc.bridges = append(bc.bridges, component[0])
+	}
+}
+
+// biconnectedFrame is an entry of the explicit stack used by HopcroftTarjan.
+type biconnectedFrame struct {
+	dfsFrame
+	edgeIndex int
+}
+
+// HopcroftTarjan computes the bridges, articulation points and biconnected
+// components of an undirected graph using the Hopcroft-Tarjan algorithm in
+// O(V + E). The depth first search is iterative, so it works on graphs of any
+// depth. Parallel edges are handled correctly, self-loops are ignored. If the
+// graph is directed, a non-nil error is returned.
+func HopcroftTarjan(g GraphView) (*BiconnectedComponents, error) {
+	if g.Directed() {
+		return nil, errors.New("biconnected components are only defined for undirected graphs")
+	}
+
+	bc := &BiconnectedComponents{articulation: make([]bool, g.V()), componentOf: map[[2]int]int{}}
+	disc := make([]int, g.V())
+	low := make([]int, g.V())
+	parent := make([]int, g.V())
+	edges := []Edge{}
+	counter := 0
+
+	for i := range disc {
+		disc[i] = -1
+	}
+
+	for s := 0; s < g.V(); s++ {
+		if disc[s] != -1 {
+			continue
+		}
+
+		disc[s], low[s] = counter, counter
+		counter++
+		parent[s] = -1
+		children := 0
+		frames := []biconnectedFrame{{dfsFrame: dfsFrame{v: s, edges: g.OutEdges(s)}}}
+
+		for len(frames) > 0 {
+			top := &frames[len(frames)-1]
+			x := top.v
+
+			if top.edges.HasNext() {
+				e := nextEdge(top.edges)
+				y := e.To
+
+				// skip the tree edge to the parent once, parallel edges are back edges
+				if y == parent[x] && !top.skippedParent {
+					top.skippedParent = true
+					continue
+				}
+
+				if disc[y] == -1 {
+					disc[y], low[y] = counter, counter
+					counter++
+					parent[y] = x
+
+					if x == s {
+						children++
+					}
+
+					frames = append(frames, biconnectedFrame{
+						dfsFrame:  dfsFrame{v: y, edges: g.OutEdges(y)},
+						edgeIndex: len(edges),
+					})
+					edges = append(edges, e)
+				} else if disc[y] < disc[x] {
+					// back edge to an ancestor, the reverse direction is skipped
+					if disc[y] < low[x] {
+						low[x] = disc[y]
+					}
+					edges = append(edges, e)
+				}
+
+				continue
+			}
+
+			edgeIndex := top.edgeIndex
+			frames = frames[:len(frames)-1]
+
+			if len(frames) == 0 {
+				break
+			}
+
+			p := parent[x]
+
+			if low[x] < low[p] {
+				low[p] = low[x]
+			}
+
+			// no edge from the subtree of x climbs above p
+			if low[x] >= disc[p] {
+				if p != s {
+					bc.articulation[p] = true
+				}
+
+				bc.addComponent(edges[edgeIndex:])
+				edges = edges[:edgeIndex]
+			}
+		}
+
+		if children > 1 {
+			bc.articulation[s] = true
+		}
+	}
+
+	return bc, nil
+}
@@ -0,0 +1,118 @@
+package ds
+
+import "errors"
+
+// Bipartition is the result of a bipartiteness check on an undirected graph. If the
+// graph is bipartite, it holds a two-coloring such that every edge connects vertices
+// of different colors. Otherwise it holds a cycle of odd length as a certificate.
+type Bipartition struct {
+	color    []int
+	oddCycle []int
+}
+
+// IsBipartite returns true if the graph is bipartite.
+func (b *Bipartition) IsBipartite() bool {
+	return b.oddCycle == nil
+}
+
+// Color returns the color 0 or 1 of the given vertex, or -1 if the graph is not
+// bipartite or v is not a vertex of the graph. The first vertex of every connected
+// component has color 0.
+func (b *Bipartition) Color(v int) int {
+	if !b.IsBipartite() || v < 0 || v >= len(b.color) {
+		return -1
+	}
+	return b.color[v]
+}
+
+// Colors returns the colors of all vertices, or nil if the graph is not bipartite.
+func (b *Bipartition) Colors() []int {
+	if !b.IsBipartite() {
+		return nil
+	}
+	return append([]int{}, b.color...)
+}
+
+// Sides returns the vertices of color 0 and 1 in increasing order, or nil if the
+// graph is not bipartite.
+func (b *Bipartition) Sides() ([]int, []int) {
+	if !b.IsBipartite() {
+		return nil, nil
+	}
+
+	sides := [2][]int{{}, {}}
+
+	for v, c := range b.color {
+		sides[c] = append(sides[c], v)
+	}
+
+	return sides[0], sides[1]
+}
+
+// OddCycle returns the vertices of a cycle of odd length in the order of its edges,
+// with the first vertex repeated at the end. If the graph is bipartite, nil is
+// returned.
+func (b *Bipartition) OddCycle() []int {
+	if b.oddCycle == nil {
+		return nil
+	}
+	return append([]int{}, b.oddCycle...)
+}
+
+// Bipartite checks whether an undirected graph is bipartite in O(V + E). A breadth
+// first search colors the vertices by the parity of their level; an edge between two
+// vertices of the same level parity closes an odd cycle through the search tree. If
+// the graph is directed, a non-nil error is returned.
+func Bipartite(g GraphView) (*Bipartition, error) {
+	if g.Directed() {
+		return nil, errors.New("bipartiteness is only defined for undirected graphs")
+	}
+
+	tree := newSearchTree(g.V())
+	state := make([]byte, g.V())
+	from, to := -1, -1
+	visitor := &GraphVisitor{
+		NonTreeEdge: func(x, y int) bool {
+			if tree.level[x]%2 != tree.level[y]%2 {
+				return true
+			}
+
+			from, to = x, y
+			return false
+		},
+	}
+
+	for s := 0; s < g.V(); s++ {
+		if state[s] == undiscovered && !bfs(g, tree, state, s, visitor) {
+			return &Bipartition{oddCycle: oddCycle(tree, from, to)}, nil
+		}
+	}
+
+	b := &Bipartition{color: make([]int, g.V())}
+
+	for v, level := range tree.level {
+		b.color[v] = level % 2
+	}
+
+	return b, nil
+}
+
+// oddCycle returns the cycle formed by the edge x - y and the tree paths from x and
+// y to their lowest common ancestor. Both vertices have the same level parity, and
+// in a breadth first tree their levels differ by at most one, so they are equal.
+func oddCycle(tree *SearchTree, x, y int) []int {
+	left := []int{x}
+	right := []int{y}
+
+	for x != y {
+		x, y = tree.parent[x], tree.parent[y]
+		left = append(left, x)
+		right = append(right, y)
+	}
+
+	// ancestor -> ... -> x, then y -> ... -> ancestor
+	reverseInts(left)
+	cycle := append(left, right[:len(right)-1]...)
+
+	return append(cycle, cycle[0])
+}
@@ -0,0 +1,161 @@
+package ds
+
+import "errors"
+
+// CSRGraph is an immutable graph in compressed sparse row format. The edges leaving
+// vertex x are stored at positions offsets[x] to offsets[x+1] - 1 of the targets and
+// weights arrays, which makes it much more compact and cache friendly than the
+// linked adjacency lists of Graph. Like Graph, undirected edges are stored in both
+// directions. CSRGraph implements GraphView.
+type CSRGraph struct {
+	offsets  []int
+	targets  []int
+	weights  []int
+	directed bool
+}
+
+// NewCSRGraph returns a CSR copy of the given graph with the same adjacency order.
+func NewCSRGraph(g *Graph) *CSRGraph {
+	c := &CSRGraph{
+		offsets:  make([]int, g.v+1),
+		targets:  make([]int, 0, g.e),
+		weights:  make([]int, 0, g.e),
+		directed: g.directed,
+	}
+
+	for x := 0; x < g.v; x++ {
+		for e := g.adj[x]; e != nil; e = e.next {
+			c.targets = append(c.targets, e.y)
+			c.weights = append(c.weights, e.weight)
+		}
+
+		c.offsets[x+1] = len(c.targets)
+	}
+
+	return c
+}
+
+// NewCSRGraphFromEdges builds a CSR graph with v vertices directly from a stream of
+// edges, without materializing a Graph. The edges leaving every vertex keep the
+// order of the stream. It buffers the edges once and sorts them by source vertex
+// with a counting sort in O(V + E). If an edge has an endpoint that is not a vertex
+// of the graph, a non-nil error is returned.
+func NewCSRGraphFromEdges(v int, directed bool, edges Iterator[Edge]) (*CSRGraph, error) {
+	if v < 0 {
+		return nil, errors.New("number of vertices must be non-negative")
+	}
+
+	c := &CSRGraph{offsets: make([]int, v+1), directed: directed}
+	from, to, weights := []int{}, []int{}, []int{}
+
+	for edges.HasNext() {
+		e, err := edges.GetNext()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := validateViewVertex(c, e.From); err != nil {
+			return nil, err
+		}
+
+		if err := validateViewVertex(c, e.To); err != nil {
+			return nil, err
+		}
+
+		from, to, weights = append(from, e.From), append(to, e.To), append(weights, e.Weight)
+		c.offsets[e.From+1]++
+
+		if !directed {
+			from, to, weights = append(from, e.To), append(to, e.From), append(weights, e.Weight)
+			c.offsets[e.To+1]++
+		}
+	}
+
+	for x := 0; x < v; x++ {
+		c.offsets[x+1] += c.offsets[x]
+	}
+
+	c.targets = make([]int, len(to))
+	c.weights = make([]int, len(to))
+	next := append([]int{}, c.offsets[:v]...)
+
+	for i, x := range from {
+		c.targets[next[x]] = to[i]
+		c.weights[next[x]] = weights[i]
+		next[x]++
+	}
+
+	return c, nil
+}
+
+// V returns the number of vertices in the graph.
+func (c *CSRGraph) V() int {
+	return len(c.offsets) - 1
+}
+
+// E returns the number of edges in the graph. Like Graph.E, every undirected edge
+// is counted twice.
+func (c *CSRGraph) E() int {
+	return len(c.targets)
+}
+
+// Directed returns true if the graph is directed, false otherwise.
+func (c *CSRGraph) Directed() bool {
+	return c.directed
+}
+
+// Degree returns the number of edges leaving vertex x in O(1). If x is not a vertex
+// of the graph, a non-nil error is returned.
+func (c *CSRGraph) Degree(x int) (int, error) {
+	if err := validateViewVertex(c, x); err != nil {
+		return 0, err
+	}
+	return c.offsets[x+1] - c.offsets[x], nil
+}
+
+// OutEdges returns an iterator over the edges leaving vertex x, which must be a
+// vertex of the graph.
+func (c *CSRGraph) OutEdges(x int) Iterator[Edge] {
+	return &csrEdgeIterator{c, x, c.offsets[x], c.offsets[x+1]}
+}
+
+// ToGraph returns a Graph with the same adjacency order.
+func (c *CSRGraph) ToGraph() *Graph {
+	g := NewGraph(c.V(), c.directed)
+
+	for x := 0; x < c.V(); x++ {
+		for i := c.offsets[x+1] - 1; i >= c.offsets[x]; i-- {
+			g.adj[x] = &Vertex{c.targets[i], c.weights[i], g.adj[x]}
+		}
+	}
+
+	g.e = len(c.targets)
+
+	return g
+}
+
+// csrEdgeIterator iterates over the edges leaving a vertex of a CSRGraph.
+type csrEdgeIterator struct {
+	c    *CSRGraph
+	x    int
+	next int
+	end  int
+}
+
+// GetNext returns the next edge. If there are no more edges, a non-nil error is
+// returned.
+func (it *csrEdgeIterator) GetNext() (Edge, error) {
+	if it.next == it.end {
+		return Edge{}, errors.New("no more edges")
+	}
+
+	e := Edge{it.x, it.c.targets[it.next], it.c.weights[it.next]}
+	it.next++
+
+	return e, nil
+}
+
+// HasNext returns true if there are more edges.
+func (it *csrEdgeIterator) HasNext() bool {
+	return it.next != it.end
+}
@@ -0,0 +1,94 @@
+package ds
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// WriteDIMACS writes the graph in the DIMACS shortest path format: a problem line
+// "p sp V E" followed by one arc line "a x y w" per adjacency entry, with vertices
+// numbered from 1. The format is directed, so undirected edges are written as two
+// arcs. It returns the first error of the writer.
+func (g *Graph) WriteDIMACS(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "p sp %d %d\n", g.v, g.e)
+
+	for x := 0; x < g.v; x++ {
+		for e := g.adj[x]; e != nil; e = e.next {
+			fmt.Fprintf(bw, "a %d %d %d\n", x+1, e.y+1, e.weight)
+		}
+	}
+
+	return bw.Flush()
+}
+
+// ReadDIMACS reads a directed graph in the DIMACS shortest path format. Comment
+// lines start with "c", the problem line "p sp V E" must precede the E arc lines
+// "a x y w", and vertices are numbered from 1. The input is parsed line by line. If
+// it is malformed or has more than MAX_READ_VERTICES vertices, a non-nil error
+// naming the offending line is returned.
+func ReadDIMACS(r io.Reader) (*Graph, error) {
+	lr := newLineReader(r)
+	var g *Graph
+	arcs := 0
+
+	for {
+		line, ok, err := lr.next()
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			break
+		}
+
+		kind, rest := cutField(line)
+
+		switch {
+		case kind == "c":
+			continue
+		case kind == "p" && g == nil:
+			var header []int
+			format, counts := cutField(rest)
+
+			if format == "sp" {
+				header, err = parseInts(counts, 2)
+			}
+
+			if format != "sp" || err != nil || header[0] < 0 || header[1] < 0 {
+				return nil, fmt.Errorf("line %d: invalid problem line %q, expected \"p sp V E\"", lr.number, line)
+			}
+
+			if err := validateVertexCount(header[0]); err != nil {
+				return nil, fmt.Errorf("line %d: %v", lr.number, err)
+			}
+
+			g = NewGraph(header[0], true)
+			arcs = header[1]
+		case kind == "a" && g != nil:
+			arc, err := parseInts(rest, 3)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid arc %q, expected \"a x y w\"", lr.number, line)
+			}
+
+			if err := g.AddEdge(arc[0]-1, arc[1]-1, arc[2]); err != nil {
+				return nil, fmt.Errorf("line %d: %v", lr.number, err)
+			}
+		case kind == "a" || kind == "p":
+			return nil, fmt.Errorf("line %d: expected exactly one problem line before the arcs", lr.number)
+		default:
+			return nil, fmt.Errorf("line %d: unknown line type %q", lr.number, kind)
+		}
+	}
+
+	if g == nil {
+		return nil, fmt.Errorf("line %d: missing problem line \"p sp V E\"", lr.number+1)
+	}
+
+	if g.e != arcs {
+		return nil, fmt.Errorf("line %d: expected %d arcs, got %d", lr.number+1, arcs, g.e)
+	}
+
+	return g, nil
+}
@@ -0,0 +1,470 @@
+package ds
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// DOTOptions controls the output of Graph.WriteDOT. The zero value writes the plain
+// graph.
+type DOTOptions struct {
+	// Name is the name of the graph, "G" if empty.
+	Name string
+	// VertexLabels holds a label for every vertex. Vertices without a label or with
+	// an empty label are labeled with their number.
+	VertexLabels []string
+	// HighlightPath is a sequence of vertices whose consecutive pairs are joined by an
+	// edge, e.g. a shortest path. The lightest edge of every pair and the vertices of
+	// the path are highlighted.
+	HighlightPath []int
+	// HighlightEdges is a set of edges to highlight, e.g. a minimum spanning tree.
+	// Edges are matched by their endpoints and weight.
+	HighlightEdges []Edge
+}
+
+// DOT attributes of highlighted vertices and edges
+const (
+	DOT_HIGHLIGHT_VERTEX = `color=red, fontcolor=red`
+	DOT_HIGHLIGHT_EDGE   = `color=red, fontcolor=red, penwidth=2`
+)
+
+// WriteDOT writes the graph in the DOT language of Graphviz. Directed graphs are
+// written as digraph with edges "x -> y", undirected graphs as graph with edges
+// "x -- y", each edge once. Every vertex is declared so that isolated vertices are
+// kept, and every edge is labeled with its weight. It returns the first error of the
+// writer.
+func (g *Graph) WriteDOT(w io.Writer, options *DOTOptions) error {
+	if options == nil {
+		options = &DOTOptions{}
+	}
+
+	kind, op := "graph", "--"
+	if g.directed {
+		kind, op = "digraph", "->"
+	}
+
+	name := options.Name
+	if name == "" {
+		name = "G"
+	}
+
+	edges := g.Edges()
+	highlighted := g.highlightedEdges(edges, options)
+	onPath := make([]bool, g.v)
+
+	for _, v := range options.HighlightPath {
+		if v >= 0 && v < g.v {
+			onPath[v] = true
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "%s %s {\n", kind, quoteDOT(name))
+
+	for v := 0; v < g.v; v++ {
+		attributes := []string{}
+
+		if v < len(options.VertexLabels) && options.VertexLabels[v] != "" {
+			attributes = append(attributes, "label="+quoteDOT(options.VertexLabels[v]))
+		}
+
+		if onPath[v] {
+			attributes = append(attributes, DOT_HIGHLIGHT_VERTEX)
+		}
+
+		if len(attributes) == 0 {
+			fmt.Fprintf(bw, "  %d;\n", v)
+		} else {
+			fmt.Fprintf(bw, "  %d [%s];\n", v, strings.Join(attributes, ", "))
+		}
+	}
+
+	for i, e := range edges {
+		if highlighted[i] {
+			fmt.Fprintf(bw, "  %d %s %d [label=%d, %s];\n", e.From, op, e.To, e.Weight, DOT_HIGHLIGHT_EDGE)
+		} else {
+			fmt.Fprintf(bw, "  %d %s %d [label=%d];\n", e.From, op, e.To, e.Weight)
+		}
+	}
+
+	fmt.Fprintln(bw, "}")
+
+	return bw.Flush()
+}
+
+// highlightedEdges marks the given edges of the graph that are highlighted by the
+// options. Every highlighted edge or path step marks at most one edge.
+func (g *Graph) highlightedEdges(edges []Edge, options *DOTOptions) []bool {
+	highlighted := make([]bool, len(edges))
+	key := func(x, y, weight int) Edge {
+		if !g.directed && x > y {
+			x, y = y, x
+		}
+		return Edge{x, y, weight}
+	}
+
+	wanted := map[Edge]int{}
+	for _, e := range options.HighlightEdges {
+		wanted[key(e.From, e.To, e.Weight)]++
+	}
+
+	for i, e := range edges {
+		if k := key(e.From, e.To, e.Weight); wanted[k] > 0 {
+			wanted[k]--
+			highlighted[i] = true
+		}
+	}
+
+	// every step of the path highlights the lightest edge between its vertices
+	steps := map[[2]int]int{}
+	for i := 0; i+1 < len(options.HighlightPath); i++ {
+		k := key(options.HighlightPath[i], options.HighlightPath[i+1], 0)
+		steps[[2]int{k.From, k.To}] = -1
+	}
+
+	for i, e := range edges {
+		pair := [2]int{e.From, e.To}
+
+		if j, ok := steps[pair]; ok && (j == -1 || e.Weight < edges[j].Weight) {
+			steps[pair] = i
+		}
+	}
+
+	for _, i := range steps {
+		if i != -1 {
+			highlighted[i] = true
+		}
+	}
+
+	return highlighted
+}
+
+// quoteDOT returns s as a quoted DOT string.
+func quoteDOT(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// ReadDOT reads a graph in the subset of the DOT language written by WriteDOT: a
+// graph or digraph whose statements declare vertices "x [label=...]" and edges
+// "x -- y" or "x -> y" with the weight as label, where vertices are non-negative
+// integers. Edges without a label have weight 1. The graph has as many vertices as
+// the largest vertex number plus one. It returns the graph and the vertex labels,
+// which are empty for vertices without a label. Other attributes are ignored. If the
+// input is malformed or a vertex number is not below MAX_READ_VERTICES, a non-nil
+// error naming the offending line is returned.
+func ReadDOT(r io.Reader) (*Graph, []string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	p := &dotParser{input: string(data), line: 1, labels: map[int]string{}}
+
+	if err := p.parse(); err != nil {
+		return nil, nil, err
+	}
+
+	g := NewGraph(p.v, p.directed)
+
+	for _, e := range p.edges {
+		g.AddEdge(e.From, e.To, e.Weight)
+	}
+
+	labels := make([]string, p.v)
+	for v, label := range p.labels {
+		labels[v] = label
+	}
+
+	return g, labels, nil
+}
+
+// dotToken is a token of the DOT language: an identifier, number or quoted string,
+// an edge operator or a single punctuation character.
+type dotToken struct {
+	text   string
+	quoted bool
+	line   int
+}
+
+// dotParser is a recursive descent parser for the subset of DOT read by ReadDOT.
+type dotParser struct {
+	input    string
+	pos      int
+	line     int
+	token    dotToken
+	directed bool
+	v        int
+	edges    []Edge
+	labels   map[int]string
+}
+
+// parse parses a whole graph.
+func (p *dotParser) parse() error {
+	if err := p.advance(); err != nil {
+		return err
+	}
+
+	switch {
+	case p.token.text == "digraph" && !p.token.quoted:
+		p.directed = true
+	case p.token.text == "graph" && !p.token.quoted:
+	default:
+		return p.errorf("expected graph or digraph, got %q", p.token.text)
+	}
+
+	if err := p.advance(); err != nil {
+		return err
+	}
+
+	if p.token.text != "{" || p.token.quoted {
+		// skip the name of the graph
+		if err := p.advance(); err != nil {
+			return err
+		}
+	}
+
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+
+	for !p.is("}") {
+		if p.atEnd() {
+			return p.errorf("unexpected end of input, expected }")
+		}
+
+		if err := p.statement(); err != nil {
+			return err
+		}
+	}
+
+	if err := p.advance(); err != nil {
+		return err
+	}
+
+	if !p.atEnd() {
+		return p.errorf("unexpected %q after the end of the graph", p.token.text)
+	}
+
+	return nil
+}
+
+// statement parses a vertex or edge statement with an optional attribute list.
+func (p *dotParser) statement() error {
+	line := p.token.line
+
+	x, err := p.vertex()
+	if err != nil {
+		return err
+	}
+
+	op := p.token.text
+	isEdge := !p.token.quoted && (op == "--" || op == "->")
+
+	if isEdge && (op == "->") != p.directed {
+		return p.errorf("edge operator %s does not match the graph type", op)
+	}
+
+	y := -1
+	if isEdge {
+		if err := p.advance(); err != nil {
+			return err
+		}
+
+		if y, err = p.vertex(); err != nil {
+			return err
+		}
+	}
+
+	attributes, err := p.attributes()
+	if err != nil {
+		return err
+	}
+
+	label, hasLabel := attributes["label"]
+
+	if !isEdge {
+		if hasLabel {
+			p.labels[x] = label
+		}
+	} else {
+		weight := 1
+
+		if hasLabel {
+			if weight, err = strconv.Atoi(label); err != nil {
+				return fmt.Errorf("line %d: edge %d %s %d has a label %q that is not an integer weight", line, x, op, y, label)
+			}
+		}
+
+		p.edges = append(p.edges, Edge{x, y, weight})
+	}
+
+	if p.is(";") {
+		return p.advance()
+	}
+
+	return nil
+}
+
+// vertex parses a vertex number.
+func (p *dotParser) vertex() (int, error) {
+	v, err := strconv.Atoi(p.token.text)
+
+	if err != nil || v < 0 {
+		return -1, p.errorf("expected a vertex number, got %q", p.token.text)
+	}
+
+	if v >= MAX_READ_VERTICES {
+		return -1, p.errorf("vertex %d exceeds the limit of %d vertices", v, MAX_READ_VERTICES)
+	}
+
+	if v >= p.v {
+		p.v = v + 1
+	}
+
+	return v, p.advance()
+}
+
+// attributes parses an optional attribute list "[key=value, ...]".
+func (p *dotParser) attributes() (map[string]string, error) {
+	attributes := map[string]string{}
+
+	if !p.is("[") {
+		return attributes, nil
+	}
+
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	for !p.is("]") {
+		key := p.token
+
+		if p.atEnd() || (!key.quoted && strings.ContainsAny(key.text, "{}[]=;,")) {
+			return nil, p.errorf("expected an attribute name, got %q", key.text)
+		}
+
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		if err := p.expect("="); err != nil {
+			return nil, err
+		}
+
+		attributes[key.text] = p.token.text
+
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		if p.is(",") || p.is(";") {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return attributes, p.advance()
+}
+
+// is returns true if the current token is the given punctuation.
+func (p *dotParser) is(text string) bool {
+	return !p.token.quoted && p.token.text == text
+}
+
+// atEnd returns true if the input is exhausted.
+func (p *dotParser) atEnd() bool {
+	return !p.token.quoted && p.token.text == ""
+}
+
+// expect consumes the given punctuation.
+func (p *dotParser) expect(text string) error {
+	if !p.is(text) {
+		return p.errorf("expected %s, got %q", text, p.token.text)
+	}
+	return p.advance()
+}
+
+// errorf returns an error for the line of the current token.
+func (p *dotParser) errorf(format string, a ...any) error {
+	return fmt.Errorf("line %d: %s", p.token.line, fmt.Sprintf(format, a...))
+}
+
+// advance reads the next token. At the end of the input the token text is empty.
+func (p *dotParser) advance() error {
+	// skip whitespace and comments
+	for p.pos < len(p.input) {
+		switch c := p.input[p.pos]; {
+		case c == '\n':
+			p.line++
+			p.pos++
+		case c == ' ' || c == '\t' || c == '\r':
+			p.pos++
+		case strings.HasPrefix(p.input[p.pos:], "//"):
+			for p.pos < len(p.input) && p.input[p.pos] != '\n' {
+				p.pos++
+			}
+		default:
+			return p.scan()
+		}
+	}
+
+	p.token = dotToken{line: p.line}
+
+	return nil
+}
+
+// scan reads the token starting at the current position.
+func (p *dotParser) scan() error {
+	start := p.pos
+	p.token = dotToken{line: p.line}
+
+	switch c := p.input[p.pos]; {
+	case c == '"':
+		var sb strings.Builder
+
+		for p.pos++; p.pos < len(p.input) && p.input[p.pos] != '"'; p.pos++ {
+			if p.input[p.pos] == '\\' && p.pos+1 < len(p.input) && strings.IndexByte(`\"`, p.input[p.pos+1]) != -1 {
+				p.pos++
+			} else if p.input[p.pos] == '\n' {
+				p.line++
+			}
+			sb.WriteByte(p.input[p.pos])
+		}
+
+		if p.pos == len(p.input) {
+			return fmt.Errorf("line %d: unterminated string", p.token.line)
+		}
+
+		p.pos++
+		p.token.text, p.token.quoted = sb.String(), true
+	case strings.HasPrefix(p.input[p.pos:], "--"), strings.HasPrefix(p.input[p.pos:], "->"):
+		p.pos += 2
+		p.token.text = p.input[start:p.pos]
+	case strings.IndexByte("{}[]=;,", c) != -1:
+		p.pos++
+		p.token.text = p.input[start:p.pos]
+	default:
+		for p.pos < len(p.input) && isDOTIdentifier(p.input[p.pos], p.pos == start) {
+			p.pos++
+		}
+
+		if p.pos == start {
+			return fmt.Errorf("line %d: unexpected character %q", p.line, c)
+		}
+
+		p.token.text = p.input[start:p.pos]
+	}
+
+	return nil
+}
+
+// isDOTIdentifier returns true if c may appear in an unquoted identifier or number.
+// A minus sign is only allowed as the first character.
+func isDOTIdentifier(c byte, first bool) bool {
+	return c == '_' || c == '.' || (c == '-' && first) ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c >= 0x80
+}
@@ -0,0 +1,315 @@
+package ds
+
+import "errors"
+
+// heapOwner identifies the heap a node handle belongs to. Melding a heap into
+// another links their owners like a union-find forest, so handles of both heaps
+// resolve to the same owner without visiting the nodes.
+type heapOwner struct {
+	parent *heapOwner
+}
+
+// find returns the owner this owner was melded into, compressing the path.
+func (o *heapOwner) find() *heapOwner {
+	root := o
+	for root.parent != nil {
+		root = root.parent
+	}
+
+	for o.parent != nil && o.parent != root {
+		o.parent, o = root, o.parent
+	}
+
+	return root
+}
+
+// FibonacciHeapNode is a handle to an element stored in a FibonacciHeap. It can be
+// used to decrease the element's key after insertion.
+type FibonacciHeapNode[T any] struct {
+	value  T
+	owner  *heapOwner
+	parent *FibonacciHeapNode[T]
+	child  *FibonacciHeapNode[T]
+	left   *FibonacciHeapNode[T]
+	right  *FibonacciHeapNode[T]
+	degree int
+	mark   bool
+}
+
+// Value returns the element stored in the node.
+func (node *FibonacciHeapNode[T]) Value() T {
+	return node.value
+}
+
+// FibonacciHeap implements a Fibonacci min-heap. Insert, Meld and DecreaseKey run
+// in O(1) (amortized for DecreaseKey), Extract in amortized O(log n).
+type FibonacciHeap[T any] struct {
+	min         *FibonacciHeapNode[T]
+	compareFunc CompareFunc[T]
+	n           int
+	owner       *heapOwner
+}
+
+// NewFibonacciHeap creates a new Fibonacci heap. The element with the smallest value
+// according to compareFunc is at the top.
+func NewFibonacciHeap[T any](compareFunc CompareFunc[T]) *FibonacciHeap[T] {
+	return &FibonacciHeap[T]{compareFunc: compareFunc, owner: &heapOwner{}}
+}
+
+// Insert adds the given element to the heap.
+func (h *FibonacciHeap[T]) Insert(t T) {
+	h.InsertNode(t)
+}
+
+// InsertNode adds the given element to the heap and returns a handle to it.
+func (h *FibonacciHeap[T]) InsertNode(t T) *FibonacciHeapNode[T] {
+	node := &FibonacciHeapNode[T]{value: t, owner: h.owner}
+	node.left, node.right = node, node
+
+	h.addRoot(node)
+	h.n++
+
+	return node
+}
+
+// Peek returns the smallest element without removing it. If the heap is empty,
+// a non-nil error is returned.
+func (h *FibonacciHeap[T]) Peek() (T, error) {
+	if h.IsEmpty() {
+		var t T
+		return t, errors.New("cannot peek into empty heap")
+	}
+
+	return h.min.value, nil
+}
+
+// Extract removes and returns the smallest element. If the heap is empty,
+// a non-nil error is returned.
+func (h *FibonacciHeap[T]) Extract() (T, error) {
+	if h.IsEmpty() {
+		var t T
+		return t, errors.New("cannot extract from empty heap")
+	}
+
+	z := h.min
+
+	for z.child != nil {
+		child := z.child
+		h.removeFromList(child)
+
+		if child == child.right {
+			z.child = nil
+		} else {
+			z.child = child.right
+		}
+
+		child.left, child.right = child, child
+		child.parent = nil
+		h.addRoot(child)
+	}
+
+	if z == z.right {
+		h.min = nil
+	} else {
+		h.min = z.right
+		h.removeFromList(z)
+		h.consolidate()
+	}
+
+	z.left, z.right = z, z
+	z.degree = 0
+	z.owner = nil
+	h.n--
+
+	return z.value, nil
+}
+
+// Meld moves all elements of other into this heap in O(1). Handles obtained from
+// other stay valid and now refer to this heap; other is left empty.
+func (h *FibonacciHeap[T]) Meld(other *FibonacciHeap[T]) {
+	if other == nil || other == h || other.min == nil {
+		return
+	}
+
+	if h.min == nil {
+		h.min = other.min
+	} else {
+		h.splice(h.min, other.min)
+
+		if h.compareFunc(other.min.value, h.min.value) < 0 {
+			h.min = other.min
+		}
+	}
+
+	h.n += other.n
+
+	other.owner.parent = h.owner
+	other.owner = &heapOwner{}
+	other.min = nil
+	other.n = 0
+}
+
+// DecreaseKey replaces the element referred to by the given handle with a smaller
+// one. If the new element is greater than the current one, or the handle refers to
+// an element that was extracted or belongs to another heap, a non-nil error is
+// returned.
+func (h *FibonacciHeap[T]) DecreaseKey(node *FibonacciHeapNode[T], t T) error {
+	if node == nil {
+		return errors.New("cannot decrease key of nil node")
+	}
+
+	if node.owner == nil || node.owner.find() != h.owner {
+		return errors.New("node is not an element of the heap")
+	}
+
+	if h.compareFunc(t, node.value) > 0 {
+		return errors.New("new key is greater than the current key")
+	}
+
+	node.value = t
+	parent := node.parent
+
+	if parent != nil && h.compareFunc(node.value, parent.value) < 0 {
+		h.cut(node, parent)
+		h.cascadingCut(parent)
+	}
+
+	if h.compareFunc(node.value, h.min.value) < 0 {
+		h.min = node
+	}
+
+	return nil
+}
+
+// Size returns the number of elements in the heap.
+func (h *FibonacciHeap[T]) Size() int {
+	return h.n
+}
+
+// IsEmpty returns true if the heap is empty, false otherwise.
+func (h *FibonacciHeap[T]) IsEmpty() bool {
+	return h.n == 0
+}
+
+// addRoot adds a single node to the root list and updates the minimum.
+func (h *FibonacciHeap[T]) addRoot(node *FibonacciHeapNode[T]) {
+	if h.min == nil {
+		h.min = node
+		return
+	}
+
+	h.splice(h.min, node)
+
+	if h.compareFunc(node.value, h.min.value) < 0 {
+		h.min = node
+	}
+}
+
+// splice joins the two circular lists containing a and b.
+func (h *FibonacciHeap[T]) splice(a, b *FibonacciHeapNode[T]) {
+	aRight, bLeft := a.right, b.left
+
+	a.right = b
+	b.left = a
+	bLeft.right = aRight
+	aRight.left = bLeft
+}
+
+// removeFromList unlinks the given node from the circular list it belongs to.
+func (h *FibonacciHeap[T]) removeFromList(node *FibonacciHeapNode[T]) {
+	node.left.right = node.right
+	node.right.left = node.left
+}
+
+// consolidate links roots of equal degree until all roots have distinct degrees
+// and finds the new minimum.
+func (h *FibonacciHeap[T]) consolidate() {
+	roots := []*FibonacciHeapNode[T]{h.min}
+	for x := h.min.right; x != h.min; x = x.right {
+		roots = append(roots, x)
+	}
+
+	byDegree := []*FibonacciHeapNode[T]{}
+
+	for _, x := range roots {
+		d := x.degree
+
+		for d < len(byDegree) && byDegree[d] != nil {
+			y := byDegree[d]
+
+			if h.compareFunc(y.value, x.value) < 0 {
+				x, y = y, x
+			}
+
+			h.link(y, x)
+			byDegree[d] = nil
+			d++
+		}
+
+		for d >= len(byDegree) {
+			byDegree = append(byDegree, nil)
+		}
+
+		byDegree[d] = x
+	}
+
+	h.min = nil
+
+	for _, x := range byDegree {
+		if x == nil {
+			continue
+		}
+
+		x.left, x.right = x, x
+		h.addRoot(x)
+	}
+}
+
+// link makes the root y a child of the root x.
+func (h *FibonacciHeap[T]) link(y, x *FibonacciHeapNode[T]) {
+	h.removeFromList(y)
+	y.left, y.right = y, y
+	y.parent = x
+
+	if x.child == nil {
+		x.child = y
+	} else {
+		h.splice(x.child, y)
+	}
+
+	x.degree++
+	y.mark = false
+}
+
+// cut moves node from the child list of parent to the root list.
+func (h *FibonacciHeap[T]) cut(node, parent *FibonacciHeapNode[T]) {
+	if node.right == node {
+		parent.child = nil
+	} else {
+		if parent.child == node {
+			parent.child = node.right
+		}
+		h.removeFromList(node)
+	}
+
+	parent.degree--
+
+	node.left, node.right = node, node
+	node.parent = nil
+	node.mark = false
+
+	h.splice(h.min, node)
+}
+
+// cascadingCut cuts marked ancestors of the given node until an unmarked one is found.
+func (h *FibonacciHeap[T]) cascadingCut(node *FibonacciHeapNode[T]) {
+	for parent := node.parent; parent != nil; parent = node.parent {
+		if !node.mark {
+			node.mark = true
+			return
+		}
+
+		h.cut(node, parent)
+		node = parent
+	}
+}
@@ -0,0 +1,213 @@
+package ds
+
+import (
+	"errors"
+	"fmt"
+)
+
+// FlowEdge is an edge of a flow network carrying Flow units of at most Capacity,
+// each unit at the given Cost.
+type FlowEdge struct {
+	From     int
+	To       int
+	Capacity int
+	Cost     int
+	Flow     int
+}
+
+// FlowNetwork is a directed network whose edges have a capacity and a cost per
+// unit of flow. It is stored as a residual network: every edge is paired with a
+// reverse arc of capacity 0 and negated cost, so edge i is stored as arc 2i.
+type FlowNetwork struct {
+	head     []int
+	next     []int
+	to       []int
+	capacity []int
+	cost     []int
+	flow     []int
+}
+
+// NewFlowNetwork creates a new flow network with v vertices and no edges.
+func NewFlowNetwork(v int) *FlowNetwork {
+	fn := &FlowNetwork{head: make([]int, v)}
+
+	for i := range fn.head {
+		fn.head[i] = -1
+	}
+
+	return fn
+}
+
+// NewFlowNetworkFromGraph creates a flow network with an edge for every adjacency
+// entry of the graph, using the edge weights as capacities and cost(x, y, weight)
+// as cost per unit. Undirected edges thus become a pair of opposite edges. If cost
+// is nil, all costs are 0. If a capacity is negative, a non-nil error is returned.
+func NewFlowNetworkFromGraph(g GraphView, cost func(x, y, weight int) int) (*FlowNetwork, error) {
+	fn := NewFlowNetwork(g.V())
+
+	for x := 0; x < g.V(); x++ {
+		for it := g.OutEdges(x); it.HasNext(); {
+			e := nextEdge(it)
+			c := 0
+			if cost != nil {
+				c = cost(x, e.To, e.Weight)
+			}
+
+			if _, err := fn.AddEdge(x, e.To, e.Weight, c); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return fn, nil
+}
+
+// V returns the number of vertices in the network.
+func (fn *FlowNetwork) V() int {
+	return len(fn.head)
+}
+
+// E returns the number of edges in the network.
+func (fn *FlowNetwork) E() int {
+	return len(fn.to) / 2
+}
+
+// AddEdge adds an edge x -> y with the given capacity and cost per unit of flow and
+// returns its id. If x or y are not vertices of the network or the capacity is
+// negative, a non-nil error is returned.
+func (fn *FlowNetwork) AddEdge(x, y, capacity, cost int) (int, error) {
+	if err := fn.validateVertex(x); err != nil {
+		return -1, err
+	}
+
+	if err := fn.validateVertex(y); err != nil {
+		return -1, err
+	}
+
+	if capacity < 0 {
+		return -1, fmt.Errorf("edge %d -> %d has negative capacity %d", x, y, capacity)
+	}
+
+	return fn.addArc(x, y, capacity, cost) / 2, nil
+}
+
+// Edge returns the edge with the given id. If there is no such edge, a non-nil
+// error is returned.
+func (fn *FlowNetwork) Edge(id int) (FlowEdge, error) {
+	if id < 0 || id >= fn.E() {
+		return FlowEdge{}, fmt.Errorf("edge id %d is out of bounds for a network with %d edges", id, fn.E())
+	}
+
+	return fn.edge(2 * id), nil
+}
+
+// Edges returns all edges of the network with their current flow, indexed by id.
+func (fn *FlowNetwork) Edges() []FlowEdge {
+	edges := make([]FlowEdge, 0, fn.E())
+
+	for a := 0; a < len(fn.to); a += 2 {
+		edges = append(edges, fn.edge(a))
+	}
+
+	return edges
+}
+
+// TotalCost returns the cost of the current flow.
+func (fn *FlowNetwork) TotalCost() int {
+	total := 0
+
+	for a := 0; a < len(fn.to); a += 2 {
+		total += fn.flow[a] * fn.cost[a]
+	}
+
+	return total
+}
+
+// Reset removes all flow from the network.
+func (fn *FlowNetwork) Reset() {
+	for a := range fn.flow {
+		fn.flow[a] = 0
+	}
+}
+
+// validateVertex returns a non-nil error if x is not a vertex of the network.
+func (fn *FlowNetwork) validateVertex(x int) error {
+	if x < 0 || x >= len(fn.head) {
+		return fmt.Errorf("vertex %d is out of bounds for a network with %d vertices", x, len(fn.head))
+	}
+	return nil
+}
+
+// validateEndpoints returns a non-nil error if s and t are not two distinct
+// vertices of the network.
+func (fn *FlowNetwork) validateEndpoints(s, t int) error {
+	if err := fn.validateVertex(s); err != nil {
+		return err
+	}
+
+	if err := fn.validateVertex(t); err != nil {
+		return err
+	}
+
+	if s == t {
+		return errors.New("source and sink must be different vertices")
+	}
+
+	return nil
+}
+
+// addArc adds an arc x -> y and its reverse arc, and returns the id of the new arc.
+func (fn *FlowNetwork) addArc(x, y, capacity, cost int) int {
+	id := len(fn.to)
+
+	fn.to = append(fn.to, y, x)
+	fn.capacity = append(fn.capacity, capacity, 0)
+	fn.cost = append(fn.cost, cost, -cost)
+	fn.flow = append(fn.flow, 0, 0)
+	fn.next = append(fn.next, fn.head[x], fn.head[y])
+	fn.head[x] = id
+	fn.head[y] = id + 1
+
+	return id
+}
+
+// edge returns the given arc as a FlowEdge.
+func (fn *FlowNetwork) edge(a int) FlowEdge {
+	return FlowEdge{fn.from(a), fn.to[a], fn.capacity[a], fn.cost[a], fn.flow[a]}
+}
+
+// from returns the tail of the given arc.
+func (fn *FlowNetwork) from(a int) int {
+	return fn.to[a^1]
+}
+
+// residual returns the remaining capacity of the given arc.
+func (fn *FlowNetwork) residual(a int) int {
+	return fn.capacity[a] - fn.flow[a]
+}
+
+// push sends the given amount of flow along arc a.
+func (fn *FlowNetwork) push(a, amount int) {
+	fn.flow[a] += amount
+	fn.flow[a^1] -= amount
+}
+
+// reachable returns the vertices reachable from s in the residual network.
+func (fn *FlowNetwork) reachable(s int) []bool {
+	marked := make([]bool, len(fn.head))
+	marked[s] = true
+	queue := []int{s}
+
+	for head := 0; head < len(queue); head++ {
+		x := queue[head]
+
+		for a := fn.head[x]; a != -1; a = fn.next[a] {
+			if y := fn.to[a]; !marked[y] && fn.residual(a) > 0 {
+				marked[y] = true
+				queue = append(queue, y)
+			}
+		}
+	}
+
+	return marked
+}
@@ -1,6 +1,7 @@
 package ds
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -12,6 +13,13 @@ type Vertex struct {
 	next   *Vertex
 }
 
+// Edge is a weighted edge from vertex From to vertex To.
+type Edge struct {
+	From   int
+	To     int
+	Weight int
+}
+
 type Graph struct {
 	v   int
 	e   int
@@ -39,8 +47,22 @@ func (g *Graph) E() int {
 	return g.e
 }
 
-// AddEdge adds an edge between vertices x and y with the given weight.
-func (g *Graph) AddEdge(x, y, weight int) {
+// Directed returns true if the graph is directed, false otherwise.
+func (g *Graph) Directed() bool {
+	return g.directed
+}
+
+// AddEdge adds an edge between vertices x and y with the given weight. If x or y
+// are not vertices of the graph, a non-nil error is returned.
+func (g *Graph) AddEdge(x, y, weight int) error {
+	if err := g.validateVertex(x); err != nil {
+		return err
+	}
+
+	if err := g.validateVertex(y); err != nil {
+		return err
+	}
+
 	g.adj[x] = &Vertex{y, weight, g.adj[x]}
 	g.e++
 
@@ -48,6 +70,219 @@ func (g *Graph) AddEdge(x, y, weight int) {
 		g.adj[y] = &Vertex{x, weight, g.adj[y]}
 		g.e++
 	}
+
+	return nil
+}
+
+// AddVertex adds an isolated vertex to the graph and returns it.
+func (g *Graph) AddVertex() int {
+	g.grow(g.v + 1)
+	return g.v - 1
+}
+
+// RemoveVertex removes vertex x and all edges incident to it. The vertices after x
+// are renumbered, i.e. vertex y > x becomes y - 1. If x is not a vertex of the
+// graph, a non-nil error is returned.
+func (g *Graph) RemoveVertex(x int) error {
+	if err := g.validateVertex(x); err != nil {
+		return err
+	}
+
+	g.adj = append(g.adj[:x], g.adj[x+1:]...)
+	g.v--
+	g.e = 0
+
+	for i := range g.adj {
+		var tail *Vertex
+
+		for v := g.adj[i]; v != nil; v = v.next {
+			if v.y == x {
+				continue
+			}
+
+			y := v.y
+			if y > x {
+				y--
+			}
+
+			entry := &Vertex{y, v.weight, nil}
+			if tail == nil {
+				g.adj[i] = entry
+			} else {
+				tail.next = entry
+			}
+			tail = entry
+			g.e++
+		}
+
+		if tail == nil {
+			g.adj[i] = nil
+		}
+	}
+
+	return nil
+}
+
+// RemoveEdge removes the most recently added edge between vertices x and y. If x or
+// y are not vertices of the graph or there is no such edge, a non-nil error is
+// returned.
+func (g *Graph) RemoveEdge(x, y int) error {
+	if err := g.validateVertex(x); err != nil {
+		return err
+	}
+
+	if err := g.validateVertex(y); err != nil {
+		return err
+	}
+
+	weight, ok := g.removeEntry(x, y, nil)
+	if !ok {
+		return fmt.Errorf("there is no edge between %d and %d", x, y)
+	}
+
+	g.e--
+
+	if !g.directed {
+		// remove the entry of the reverse direction, a self-loop has two entries in
+		// the same list
+		g.removeEntry(y, x, &weight)
+		g.e--
+	}
+
+	return nil
+}
+
+// removeEntry removes the first entry of y in the adjacency list of x, restricted
+// to the given weight if it is not nil. It returns the weight of the removed entry
+// and false if there is no such entry.
+func (g *Graph) removeEntry(x, y int, weight *int) (int, bool) {
+	for link := &g.adj[x]; *link != nil; link = &(*link).next {
+		if v := *link; v.y == y && (weight == nil || v.weight == *weight) {
+			*link = v.next
+			return v.weight, true
+		}
+	}
+
+	return 0, false
+}
+
+// HasEdge returns true if there is an edge between vertices x and y.
+func (g *Graph) HasEdge(x, y int) bool {
+	_, err := g.Weight(x, y)
+	return err == nil
+}
+
+// Weight returns the weight of the most recently added edge between vertices x and
+// y. If x or y are not vertices of the graph or there is no such edge, a non-nil
+// error is returned.
+func (g *Graph) Weight(x, y int) (int, error) {
+	if err := g.validateVertex(x); err != nil {
+		return 0, err
+	}
+
+	if err := g.validateVertex(y); err != nil {
+		return 0, err
+	}
+
+	for v := g.adj[x]; v != nil; v = v.next {
+		if v.y == y {
+			return v.weight, nil
+		}
+	}
+
+	return 0, fmt.Errorf("there is no edge between %d and %d", x, y)
+}
+
+// Degree returns the number of edges leaving vertex x. A self-loop of an undirected
+// graph counts twice. If x is not a vertex of the graph, a non-nil error is
+// returned.
+func (g *Graph) Degree(x int) (int, error) {
+	if err := g.validateVertex(x); err != nil {
+		return 0, err
+	}
+
+	degree := 0
+	for v := g.adj[x]; v != nil; v = v.next {
+		degree++
+	}
+
+	return degree, nil
+}
+
+// InDegree returns the number of edges entering vertex x. For undirected graphs it
+// equals the degree, for directed graphs it takes O(V + E). If x is not a vertex of
+// the graph, a non-nil error is returned.
+func (g *Graph) InDegree(x int) (int, error) {
+	if !g.directed {
+		return g.Degree(x)
+	}
+
+	if err := g.validateVertex(x); err != nil {
+		return 0, err
+	}
+
+	degree := 0
+	for i := 0; i < g.v; i++ {
+		for v := g.adj[i]; v != nil; v = v.next {
+			if v.y == x {
+				degree++
+			}
+		}
+	}
+
+	return degree, nil
+}
+
+// Neighbors returns an iterator over the edges leaving vertex x, most recently
+// added first. If x is not a vertex of the graph, a non-nil error is returned.
+func (g *Graph) Neighbors(x int) (Iterator[Edge], error) {
+	if err := g.validateVertex(x); err != nil {
+		return nil, err
+	}
+
+	return g.OutEdges(x), nil
+}
+
+// OutEdges returns an iterator over the edges leaving vertex x, most recently added
+// first. Unlike Neighbors, it does not validate x.
+func (g *Graph) OutEdges(x int) Iterator[Edge] {
+	return &edgeIterator{x, g.adj[x]}
+}
+
+// edgeIterator iterates over an adjacency list.
+type edgeIterator struct {
+	x    int
+	next *Vertex
+}
+
+// GetNext returns the next edge. If there are no more edges, a non-nil error is
+// returned.
+func (it *edgeIterator) GetNext() (Edge, error) {
+	if it.next == nil {
+		return Edge{}, errors.New("no more edges")
+	}
+
+	e := Edge{it.x, it.next.y, it.next.weight}
+	it.next = it.next.next
+
+	return e, nil
+}
+
+// HasNext returns true if there are more edges.
+func (it *edgeIterator) HasNext() bool {
+	return it.next != nil
+}
+
+// Edges returns all edges of the graph. Each edge of an undirected graph is
+// returned only once, with From <= To.
+func (g *Graph) Edges() []Edge {
+	return viewEdges(g)
+}
+
+// Reverse returns a copy of the graph with all edges reversed. The reverse of an
+// undirected graph is a copy of the graph.
+func (g *Graph) Reverse() *Graph {
+	return reverseGraph(g)
 }
 
 // Write writes the graph to the given writer.
@@ -67,3 +302,16 @@ func (g *Graph) Write(w io.Writer) {
 func (g *Graph) Print() {
 	g.Write(os.Stdout)
 }
+
+// grow adds isolated vertices until the graph has at least v vertices.
+func (g *Graph) grow(v int) {
+	for g.v < v {
+		g.adj = append(g.adj, nil)
+		g.v++
+	}
+}
+
+// validateVertex returns a non-nil error if x is not a vertex of the graph.
+func (g *Graph) validateVertex(x int) error {
+	return validateViewVertex(g, x)
+}
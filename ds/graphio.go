@@ -0,0 +1,293 @@
+package ds
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	// MAX_READ_VERTICES is the largest number of vertices the graph readers accept.
+	// The readers allocate the vertices of a header before reading any edges, and at
+	// this limit that takes a few megabytes, so a short malformed input cannot make
+	// them exhaust memory.
+	MAX_READ_VERTICES int = 1 << 20
+)
+
+// ReadGraph reads a graph in one of two formats. Both start with a line "V E"
+// holding the number of vertices and edges.
+//
+// The adjacency format written by Graph.Write follows with one line "x: (y, w) ..."
+// per vertex listing its adjacency entries, and E counts the entries. Undirected
+// edges are listed in the adjacency lists of both vertices, so every entry must be
+// matched by its reverse. The adjacency lists are restored in the same order.
+//
+// The edge list format follows with E lines "x y w", each adding an edge from x to
+// y with weight w.
+//
+// Empty lines are ignored. If the input is malformed or has more than
+// MAX_READ_VERTICES vertices, a non-nil error naming the offending line is returned.
+func ReadGraph(r io.Reader, directed bool) (*Graph, error) {
+	lr := newLineReader(r)
+
+	line, ok, err := lr.next()
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("line %d: missing header \"V E\"", lr.number+1)
+	}
+
+	header, err := parseInts(line, 2)
+	if err != nil || header[0] < 0 || header[1] < 0 {
+		return nil, fmt.Errorf("line %d: invalid header %q, expected \"V E\"", lr.number, line)
+	}
+
+	if err := validateVertexCount(header[0]); err != nil {
+		return nil, fmt.Errorf("line %d: %v", lr.number, err)
+	}
+
+	g := NewGraph(header[0], directed)
+
+	line, ok, err = lr.next()
+	if err != nil {
+		return nil, err
+	}
+
+	if ok && strings.Contains(line, ":") {
+		err = g.readAdjacencyLists(lr, line, header[1])
+	} else {
+		err = g.readEdgeList(lr, line, ok, header[1])
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return g, nil
+}
+
+// readAdjacencyLists reads the adjacency lines of the format written by Write,
+// starting with the given line.
+func (g *Graph) readAdjacencyLists(lr *lineReader, line string, e int) error {
+	if g.v == 0 {
+		return fmt.Errorf("line %d: unexpected content %q", lr.number, line)
+	}
+
+	tails := make([]*Vertex, g.v)
+	reverse := map[Edge]int{}
+	firstLine := map[Edge]int{}
+
+	for x := 0; x < g.v; x++ {
+		if x > 0 {
+			var ok bool
+			var err error
+
+			if line, ok, err = lr.next(); err != nil {
+				return err
+			} else if !ok {
+				return fmt.Errorf("line %d: missing adjacency list of vertex %d", lr.number+1, x)
+			}
+		}
+
+		label, entries, _ := strings.Cut(line, ":")
+
+		if v, err := strconv.Atoi(strings.TrimSpace(label)); err != nil || v != x {
+			return fmt.Errorf("line %d: expected adjacency list of vertex %d, got %q", lr.number, x, line)
+		}
+
+		for entries = strings.TrimSpace(entries); entries != ""; {
+			end := strings.IndexByte(entries, ')')
+
+			if entries[0] != '(' || end == -1 {
+				return fmt.Errorf("line %d: invalid entry %q, expected \"(y, w)\"", lr.number, entries)
+			}
+
+			values, err := parseInts(strings.ReplaceAll(entries[1:end], ",", " "), 2)
+			if err != nil || strings.Count(entries[1:end], ",") != 1 {
+				return fmt.Errorf("line %d: invalid entry %q, expected \"(y, w)\"", lr.number, entries[:end+1])
+			}
+
+			y, w := values[0], values[1]
+			if err := g.validateVertex(y); err != nil {
+				return fmt.Errorf("line %d: %v", lr.number, err)
+			}
+
+			entry := &Vertex{y, w, nil}
+			if tails[x] == nil {
+				g.adj[x] = entry
+			} else {
+				tails[x].next = entry
+			}
+			tails[x] = entry
+			g.e++
+
+			if !g.directed {
+				// count every entry against its reverse, self-loops against themselves
+				key := Edge{x, y, w}
+				if y < x || (y == x && reverse[key] > 0) {
+					key = Edge{y, x, w}
+					reverse[key]--
+				} else {
+					reverse[key]++
+				}
+
+				if _, ok := firstLine[key]; !ok {
+					firstLine[key] = lr.number
+				}
+			}
+
+			entries = strings.TrimSpace(entries[end+1:])
+		}
+	}
+
+	// report the unmatched edge seen first
+	unmatched := Edge{}
+	number := 0
+
+	for key, count := range reverse {
+		if count != 0 && (number == 0 || firstLine[key] < number) {
+			unmatched, number = key, firstLine[key]
+		}
+	}
+
+	if number != 0 {
+		return fmt.Errorf("line %d: entries of edge %d - %d with weight %d are not matched by their reverse",
+			number, unmatched.From, unmatched.To, unmatched.Weight)
+	}
+
+	if g.e != e {
+		return fmt.Errorf("line %d: expected %d adjacency entries, got %d", lr.number, e, g.e)
+	}
+
+	return lr.end()
+}
+
+// readEdgeList reads e lines "x y w" starting with the given line, if any.
+func (g *Graph) readEdgeList(lr *lineReader, line string, ok bool, e int) error {
+	for i := 0; i < e; i++ {
+		if i > 0 {
+			var err error
+
+			if line, ok, err = lr.next(); err != nil {
+				return err
+			}
+		}
+
+		if !ok {
+			return fmt.Errorf("line %d: expected %d edges, got %d", lr.number+1, e, i)
+		}
+
+		edge, err := parseInts(line, 3)
+		if err != nil {
+			return fmt.Errorf("line %d: invalid edge %q, expected \"x y w\"", lr.number, line)
+		}
+
+		if err := g.AddEdge(edge[0], edge[1], edge[2]); err != nil {
+			return fmt.Errorf("line %d: %v", lr.number, err)
+		}
+	}
+
+	if e == 0 && ok {
+		return fmt.Errorf("line %d: unexpected content %q", lr.number, line)
+	}
+
+	return lr.end()
+}
+
+// validateVertexCount returns a non-nil error if v is not a valid number of vertices
+// for a graph read from input.
+func validateVertexCount(v int) error {
+	if v < 0 {
+		return fmt.Errorf("number of vertices %d is negative", v)
+	}
+
+	if v > MAX_READ_VERTICES {
+		return fmt.Errorf("number of vertices %d exceeds the limit of %d", v, MAX_READ_VERTICES)
+	}
+
+	return nil
+}
+
+// parseInts parses exactly n whitespace separated integers.
+func parseInts(s string, n int) ([]int, error) {
+	fields := strings.Fields(s)
+
+	if len(fields) != n {
+		return nil, fmt.Errorf("expected %d integers, got %d", n, len(fields))
+	}
+
+	values := make([]int, n)
+
+	for i, f := range fields {
+		v, err := strconv.Atoi(f)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+
+	return values, nil
+}
+
+// cutField returns the first whitespace separated field of s and the rest of s.
+func cutField(s string) (string, string) {
+	s = strings.TrimSpace(s)
+
+	if i := strings.IndexAny(s, " \t"); i != -1 {
+		return s[:i], s[i+1:]
+	}
+
+	return s, ""
+}
+
+// lineReader reads non-empty lines and keeps track of the line number.
+type lineReader struct {
+	r      *bufio.Reader
+	number int
+}
+
+// newLineReader returns a line reader for the given reader.
+func newLineReader(r io.Reader) *lineReader {
+	return &lineReader{r: bufio.NewReader(r)}
+}
+
+// next returns the next non-empty line without surrounding whitespace, and false if
+// the input is exhausted.
+func (lr *lineReader) next() (string, bool, error) {
+	for {
+		line, err := lr.r.ReadString('\n')
+
+		if err != nil && err != io.EOF {
+			return "", false, fmt.Errorf("line %d: %v", lr.number+1, err)
+		}
+
+		if line == "" && err == io.EOF {
+			return "", false, nil
+		}
+
+		lr.number++
+
+		if line = strings.TrimSpace(line); line != "" {
+			return line, true, nil
+		}
+	}
+}
+
+// end returns a non-nil error if there are non-empty lines left.
+func (lr *lineReader) end() error {
+	line, ok, err := lr.next()
+
+	if err != nil {
+		return err
+	}
+
+	if ok {
+		return fmt.Errorf("line %d: unexpected content %q", lr.number, line)
+	}
+
+	return nil
+}
@@ -0,0 +1,297 @@
+package ds
+
+// GraphVisitor holds optional callbacks invoked during a graph traversal. Any of the
+// callbacks may be nil. Returning false from a callback stops the traversal.
+//
+// For undirected graphs every edge is reported once: the reverse of a tree edge is
+// skipped and so are edges leading to a vertex that is already finished.
+type GraphVisitor struct {
+	// DiscoverVertex is called when a vertex is reached for the first time.
+	DiscoverVertex func(v int) bool
+	// FinishVertex is called when all edges leaving a vertex have been examined.
+	FinishVertex func(v int) bool
+	// TreeEdge is called for an edge x -> y leading to an undiscovered vertex y.
+	TreeEdge func(x, y int) bool
+	// BackEdge is called by depth first searches for an edge x -> y leading to a
+	// vertex y on the current search path, i.e. an edge closing a cycle.
+	BackEdge func(x, y int) bool
+	// NonTreeEdge is called for all remaining edges: forward and cross edges in
+	// depth first searches and edges to discovered vertices in breadth first searches.
+	NonTreeEdge func(x, y int) bool
+}
+
+func (vis *GraphVisitor) discoverVertex(v int) bool {
+	return vis == nil || vis.DiscoverVertex == nil || vis.DiscoverVertex(v)
+}
+
+func (vis *GraphVisitor) finishVertex(v int) bool {
+	return vis == nil || vis.FinishVertex == nil || vis.FinishVertex(v)
+}
+
+func (vis *GraphVisitor) treeEdge(x, y int) bool {
+	return vis == nil || vis.TreeEdge == nil || vis.TreeEdge(x, y)
+}
+
+func (vis *GraphVisitor) backEdge(x, y int) bool {
+	return vis == nil || vis.BackEdge == nil || vis.BackEdge(x, y)
+}
+
+func (vis *GraphVisitor) nonTreeEdge(x, y int) bool {
+	return vis == nil || vis.NonTreeEdge == nil || vis.NonTreeEdge(x, y)
+}
+
+// vertex states during a traversal
+const (
+	undiscovered byte = iota
+	discovered
+	finished
+)
+
+// SearchTree is the result of a graph traversal. It stores the parent of every
+// reached vertex and its level, i.e. the number of edges on the tree path from the
+// root of the search. For breadth first searches the level is the shortest distance
+// in edges from the source.
+type SearchTree struct {
+	parent  []int
+	level   []int
+	stopped bool
+}
+
+// newSearchTree returns a search tree for v vertices with no vertex reached.
+func newSearchTree(v int) *SearchTree {
+	tree := &SearchTree{parent: make([]int, v), level: make([]int, v)}
+
+	for i := 0; i < v; i++ {
+		tree.parent[i] = -1
+		tree.level[i] = -1
+	}
+
+	return tree
+}
+
+// Visited returns true if the given vertex was reached by the search.
+func (tree *SearchTree) Visited(v int) bool {
+	return v >= 0 && v < len(tree.level) && tree.level[v] >= 0
+}
+
+// Parent returns the parent of the given vertex in the search tree, or -1 if the
+// vertex is a root or was not reached.
+func (tree *SearchTree) Parent(v int) int {
+	if !tree.Visited(v) {
+		return -1
+	}
+	return tree.parent[v]
+}
+
+// Level returns the level of the given vertex in the search tree, or -1 if the
+// vertex was not reached.
+func (tree *SearchTree) Level(v int) int {
+	if !tree.Visited(v) {
+		return -1
+	}
+	return tree.level[v]
+}
+
+// Parents returns a copy of the parent array. Roots and unreached vertices have
+// the parent -1.
+func (tree *SearchTree) Parents() []int {
+	return append([]int{}, tree.parent...)
+}
+
+// Levels returns a copy of the level array. Unreached vertices have the level -1.
+func (tree *SearchTree) Levels() []int {
+	return append([]int{}, tree.level...)
+}
+
+// Stopped returns true if the traversal was terminated early by the visitor.
+func (tree *SearchTree) Stopped() bool {
+	return tree.stopped
+}
+
+// HasPathTo returns true if the given vertex was reached by the search.
+func (tree *SearchTree) HasPathTo(v int) bool {
+	return tree.Visited(v)
+}
+
+// PathTo returns the vertices on the tree path from the root of the search to the
+// given vertex. If the vertex was not reached, nil is returned.
+func (tree *SearchTree) PathTo(v int) []int {
+	if !tree.Visited(v) {
+		return nil
+	}
+
+	path := make([]int, tree.level[v]+1)
+
+	for i := len(path) - 1; i >= 0; i-- {
+		path[i] = v
+		v = tree.parent[v]
+	}
+
+	return path
+}
+
+// BreadthFirstSearch traverses the graph in breadth first order starting at vertex s.
+// If s is not a vertex of the graph, a non-nil error is returned.
+func BreadthFirstSearch(g GraphView, s int, visitor *GraphVisitor) (*SearchTree, error) {
+	if err := validateViewVertex(g, s); err != nil {
+		return nil, err
+	}
+
+	tree := newSearchTree(g.V())
+	state := make([]byte, g.V())
+	tree.stopped = !bfs(g, tree, state, s, visitor)
+
+	return tree, nil
+}
+
+// DepthFirstSearch traverses the graph in depth first order starting at vertex s.
+// The search is iterative, so it is not limited by the depth of the call stack.
+// If s is not a vertex of the graph, a non-nil error is returned.
+func DepthFirstSearch(g GraphView, s int, visitor *GraphVisitor) (*SearchTree, error) {
+	if err := validateViewVertex(g, s); err != nil {
+		return nil, err
+	}
+
+	tree := newSearchTree(g.V())
+	state := make([]byte, g.V())
+	tree.stopped = !dfs(g, tree, state, s, visitor)
+
+	return tree, nil
+}
+
+// DepthFirstSearchAll traverses the whole graph in depth first order, starting a new
+// search from every vertex not reached yet in increasing order. The result is a
+// depth first forest.
+func DepthFirstSearchAll(g GraphView, visitor *GraphVisitor) *SearchTree {
+	tree := newSearchTree(g.V())
+	state := make([]byte, g.V())
+
+	for s := 0; s < g.V(); s++ {
+		if state[s] != undiscovered {
+			continue
+		}
+
+		if !dfs(g, tree, state, s, visitor) {
+			tree.stopped = true
+			break
+		}
+	}
+
+	return tree
+}
+
+// bfs runs a breadth first search from s and returns false if it was stopped.
+func bfs(g GraphView, tree *SearchTree, state []byte, s int, visitor *GraphVisitor) bool {
+	tree.level[s] = 0
+	state[s] = discovered
+
+	if !visitor.discoverVertex(s) {
+		return false
+	}
+
+	directed := g.Directed()
+	queue := []int{s}
+
+	for head := 0; head < len(queue); head++ {
+		x := queue[head]
+
+		for it := g.OutEdges(x); it.HasNext(); {
+			y := nextEdge(it).To
+
+			switch state[y] {
+			case undiscovered:
+				tree.parent[y] = x
+				tree.level[y] = tree.level[x] + 1
+				state[y] = discovered
+
+				if !visitor.treeEdge(x, y) || !visitor.discoverVertex(y) {
+					return false
+				}
+
+				queue = append(queue, y)
+			case discovered:
+				if !visitor.nonTreeEdge(x, y) {
+					return false
+				}
+			case finished:
+				if directed && !visitor.nonTreeEdge(x, y) {
+					return false
+				}
+			}
+		}
+
+		state[x] = finished
+
+		if !visitor.finishVertex(x) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// dfsFrame is an entry of the explicit stack used by the depth first search.
+type dfsFrame struct {
+	v             int
+	edges         Iterator[Edge]
+	skippedParent bool
+}
+
+// dfs runs a depth first search from s and returns false if it was stopped.
+func dfs(g GraphView, tree *SearchTree, state []byte, s int, visitor *GraphVisitor) bool {
+	tree.level[s] = 0
+	state[s] = discovered
+
+	if !visitor.discoverVertex(s) {
+		return false
+	}
+
+	directed := g.Directed()
+	stack := []dfsFrame{{v: s, edges: g.OutEdges(s)}}
+
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		x := top.v
+
+		if !top.edges.HasNext() {
+			stack = stack[:len(stack)-1]
+			state[x] = finished
+
+			if !visitor.finishVertex(x) {
+				return false
+			}
+
+			continue
+		}
+
+		y := nextEdge(top.edges).To
+
+		if !directed && y == tree.parent[x] && !top.skippedParent {
+			top.skippedParent = true
+			continue
+		}
+
+		switch state[y] {
+		case undiscovered:
+			tree.parent[y] = x
+			tree.level[y] = tree.level[x] + 1
+			state[y] = discovered
+
+			if !visitor.treeEdge(x, y) || !visitor.discoverVertex(y) {
+				return false
+			}
+
+			stack = append(stack, dfsFrame{v: y, edges: g.OutEdges(y)})
+		case discovered:
+			if !visitor.backEdge(x, y) {
+				return false
+			}
+		case finished:
+			if directed && !visitor.nonTreeEdge(x, y) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
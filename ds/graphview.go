@@ -0,0 +1,81 @@
+package ds
+
+import "fmt"
+
+// GraphView is a read-only view of a graph with vertices 0 to V() - 1 and weighted
+// edges, implemented by Graph, CSRGraph and MatrixGraph and returned by
+// LabeledGraph.View. Algorithms taking a GraphView only iterate over the edges leaving
+// a vertex, so a GraphView may also compute its edges on demand instead of storing
+// them, e.g. the moves of a grid maze or the transitions of a state space.
+//
+// Edges of undirected graphs must be reported in both directions, a self-loop twice.
+type GraphView interface {
+	// V returns the number of vertices.
+	V() int
+	// Directed returns true if the graph is directed.
+	Directed() bool
+	// OutEdges returns an iterator over the edges leaving vertex x, which must be a
+	// vertex of the graph.
+	OutEdges(x int) Iterator[Edge]
+}
+
+// validateViewVertex returns a non-nil error if x is not a vertex of the graph.
+func validateViewVertex(g GraphView, x int) error {
+	if x < 0 || x >= g.V() {
+		return fmt.Errorf("vertex %d is out of bounds for a graph with %d vertices", x, g.V())
+	}
+	return nil
+}
+
+// nextEdge returns the next edge of the iterator, which must have one.
+func nextEdge(it Iterator[Edge]) Edge {
+	e, _ := it.GetNext()
+	return e
+}
+
+// viewEdges returns all edges of the graph. Undirected edges are returned once, with
+// From <= To.
+func viewEdges(g GraphView) []Edge {
+	edges := []Edge{}
+
+	for x := 0; x < g.V(); x++ {
+		selfLoop := false
+
+		for it := g.OutEdges(x); it.HasNext(); {
+			if e := nextEdge(it); g.Directed() || firstUndirectedEntry(x, e.To, &selfLoop) {
+				edges = append(edges, e)
+			}
+		}
+	}
+
+	return edges
+}
+
+// firstUndirectedEntry returns true if the adjacency entry x -> y of an undirected
+// graph is the first of the two entries of its edge: the entry at the smaller
+// endpoint, or of a self-loop, which is stored twice at x, the first one. selfLoop
+// tracks the self-loops seen at x and must be false before the first entry of x.
+func firstUndirectedEntry(x, y int, selfLoop *bool) bool {
+	if y != x {
+		return y > x
+	}
+
+	*selfLoop = !*selfLoop
+
+	return *selfLoop
+}
+
+// reverseGraph returns a Graph with all edges of the view reversed.
+func reverseGraph(g GraphView) *Graph {
+	r := NewGraph(g.V(), g.Directed())
+
+	for x := 0; x < g.V(); x++ {
+		for it := g.OutEdges(x); it.HasNext(); {
+			e := nextEdge(it)
+			r.adj[e.To] = &Vertex{x, e.Weight, r.adj[e.To]}
+			r.e++
+		}
+	}
+
+	return r
+}
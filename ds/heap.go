@@ -1,37 +1,93 @@
 package ds
 
+import "errors"
+
 // CompareFunc is a function that compares two values of the same type and returns
 // an integer representing the comparison. The return value should be:
-//   -1 if a < b
-type CompareFunc[T any] func(a, b T) int 
-
+//
+//	-1 (or any negative value) if a < b
+//	 0 if a == b
+//	+1 (or any positive value) if a > b
+type CompareFunc[T any] func(a, b T) int
 
-// Heap implements a heap data structure.
+// Heap implements an array based binary heap. The element at the top of the heap
+// is the one that compares greatest according to the heap's comparison function,
+// i.e. a heap created by NewHeap is a max-heap and a heap created by NewMinHeap
+// is a min-heap.
 type Heap[T any] struct {
-	data []T
+	data        []T
 	compareFunc CompareFunc[T]
-	n int
+	n           int
 }
 
-// NewHeap creates a new heap with the given capacity and comparison function.
+// NewHeap creates a new max-heap with the given capacity and comparison function.
 func NewHeap[T any](capacity int, compareFunc CompareFunc[T]) *Heap[T] {
 	return &Heap[T]{data: make([]T, capacity), compareFunc: compareFunc, n: 0}
 }
 
+// NewMinHeap creates a new min-heap with the given capacity and comparison function.
+// The comparison function is used as is, so callers do not need to invert it.
+func NewMinHeap[T any](capacity int, compareFunc CompareFunc[T]) *Heap[T] {
+	return NewHeap(capacity, reverseCompareFunc(compareFunc))
+}
+
 // Insert adds the given element to the heap.
 func (h *Heap[T]) Insert(t T) {
+	if h.n == len(h.data) {
+		h.data = ResizeSlice(h.data, 2*len(h.data)+1)
+	}
+
+	h.data[h.n] = t
+	h.n++
+	h.siftUp(h.n - 1)
+}
+
+// Peek returns the element at the top of the heap without removing it. If the
+// heap is empty, a non-nil error is returned.
+func (h *Heap[T]) Peek() (T, error) {
+	if h.IsEmpty() {
+		var t T
+		return t, errors.New("cannot peek into empty heap")
+	}
+
+	return h.data[0], nil
 }
 
-// Max returns the maximum element in the heap.
-func (h *Heap[T]) Max() T {
-	var t T
-	return t
+// Extract removes and returns the element at the top of the heap. If the heap
+// is empty, a non-nil error is returned.
+func (h *Heap[T]) Extract() (T, error) {
+	if h.IsEmpty() {
+		var t T
+		return t, errors.New("cannot extract from empty heap")
+	}
+
+	t := h.data[0]
+	h.n--
+	h.data[0] = h.data[h.n]
+
+	var zero T
+	h.data[h.n] = zero
+
+	h.siftDown(0)
+
+	return t, nil
 }
 
-// ExtractMax removes and returns the maximum element in the heap.
-func (h *Heap[T]) ExtractMax() T {
-	var t T
-	return t
+// Heapify replaces the content of the heap with the elements of the given slice
+// and restores the heap order in O(n). The slice is copied and left unmodified.
+func (h *Heap[T]) Heapify(data []T) {
+	capacity := len(data)
+	if capacity < len(h.data) {
+		capacity = len(h.data)
+	}
+
+	h.data = make([]T, capacity)
+	copy(h.data, data)
+	h.n = len(data)
+
+	for i := h.n/2 - 1; i >= 0; i-- {
+		h.siftDown(i)
+	}
 }
 
 // Size returns the number of elements in the heap.
@@ -42,4 +98,49 @@ func (h *Heap[T]) Size() int {
 // IsEmpty returns true if the heap is empty, false otherwise.
 func (h *Heap[T]) IsEmpty() bool {
 	return h.n == 0
-}
\ No newline at end of file
+}
+
+// siftUp moves the element at index i up until its parent is not smaller.
+func (h *Heap[T]) siftUp(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+
+		if h.compareFunc(h.data[parent], h.data[i]) >= 0 {
+			return
+		}
+
+		h.data[parent], h.data[i] = h.data[i], h.data[parent]
+		i = parent
+	}
+}
+
+// siftDown moves the element at index i down until none of its children is greater.
+func (h *Heap[T]) siftDown(i int) {
+	for {
+		largest := i
+		left, right := 2*i+1, 2*i+2
+
+		if left < h.n && h.compareFunc(h.data[left], h.data[largest]) > 0 {
+			largest = left
+		}
+
+		if right < h.n && h.compareFunc(h.data[right], h.data[largest]) > 0 {
+			largest = right
+		}
+
+		if largest == i {
+			return
+		}
+
+		h.data[i], h.data[largest] = h.data[largest], h.data[i]
+		i = largest
+	}
+}
+
+// reverseCompareFunc returns a comparison function imposing the reverse ordering
+// of the given one.
+func reverseCompareFunc[T any](compareFunc CompareFunc[T]) CompareFunc[T] {
+	return func(a, b T) int {
+		return compareFunc(b, a)
+	}
+}
@@ -0,0 +1,231 @@
+package ds
+
+import (
+	"errors"
+	"fmt"
+)
+
+// IndexedPriorityQueue implements a binary min-heap of keys, each associated with a
+// priority. In contrast to Heap, the priority of a key already in the queue can be
+// changed and arbitrary keys can be deleted, both in O(log n).
+type IndexedPriorityQueue[K comparable, P any] struct {
+	keys        []K
+	priorities  []P
+	index       map[K]int
+	compareFunc CompareFunc[P]
+	n           int
+}
+
+// NewIndexedPriorityQueue creates a new indexed priority queue with the given capacity.
+// The key with the smallest priority according to compareFunc is at the top.
+func NewIndexedPriorityQueue[K comparable, P any](capacity int, compareFunc CompareFunc[P]) *IndexedPriorityQueue[K, P] {
+	return &IndexedPriorityQueue[K, P]{
+		keys:        make([]K, capacity),
+		priorities:  make([]P, capacity),
+		index:       make(map[K]int, capacity),
+		compareFunc: compareFunc,
+	}
+}
+
+// Insert adds the given key with the given priority to the queue. If the key is
+// already present, a non-nil error is returned.
+func (pq *IndexedPriorityQueue[K, P]) Insert(key K, priority P) error {
+	if pq.Contains(key) {
+		return fmt.Errorf("key %v is already in the priority queue", key)
+	}
+
+	if pq.n == len(pq.keys) {
+		pq.keys = ResizeSlice(pq.keys, 2*len(pq.keys)+1)
+		pq.priorities = ResizeSlice(pq.priorities, 2*len(pq.priorities)+1)
+	}
+
+	pq.keys[pq.n] = key
+	pq.priorities[pq.n] = priority
+	pq.index[key] = pq.n
+	pq.n++
+	pq.siftUp(pq.n - 1)
+
+	return nil
+}
+
+// Contains returns true if the given key is in the queue, false otherwise.
+func (pq *IndexedPriorityQueue[K, P]) Contains(key K) bool {
+	_, ok := pq.index[key]
+	return ok
+}
+
+// Priority returns the priority associated with the given key. If the key is not
+// in the queue, a zero value and a false flag are returned.
+func (pq *IndexedPriorityQueue[K, P]) Priority(key K) (P, bool) {
+	i, ok := pq.index[key]
+
+	if !ok {
+		var p P
+		return p, false
+	}
+
+	return pq.priorities[i], true
+}
+
+// ChangePriority sets the priority of the given key to the given value. If the key
+// is not in the queue, a non-nil error is returned.
+func (pq *IndexedPriorityQueue[K, P]) ChangePriority(key K, priority P) error {
+	i, ok := pq.index[key]
+
+	if !ok {
+		return fmt.Errorf("key %v is not in the priority queue", key)
+	}
+
+	pq.priorities[i] = priority
+	pq.siftUp(i)
+	pq.siftDown(pq.index[key])
+
+	return nil
+}
+
+// DecreaseKey lowers the priority of the given key to the given value. A non-nil
+// error is returned if the key is not in the queue or the new priority is greater
+// than the current one.
+func (pq *IndexedPriorityQueue[K, P]) DecreaseKey(key K, priority P) error {
+	current, ok := pq.Priority(key)
+
+	if ok && pq.compareFunc(priority, current) > 0 {
+		return fmt.Errorf("new priority of key %v is greater than the current one", key)
+	}
+
+	return pq.ChangePriority(key, priority)
+}
+
+// IncreaseKey raises the priority of the given key to the given value. A non-nil
+// error is returned if the key is not in the queue or the new priority is smaller
+// than the current one.
+func (pq *IndexedPriorityQueue[K, P]) IncreaseKey(key K, priority P) error {
+	current, ok := pq.Priority(key)
+
+	if ok && pq.compareFunc(priority, current) < 0 {
+		return fmt.Errorf("new priority of key %v is smaller than the current one", key)
+	}
+
+	return pq.ChangePriority(key, priority)
+}
+
+// Delete removes the given key from the queue. If the key is not in the queue,
+// a non-nil error is returned.
+func (pq *IndexedPriorityQueue[K, P]) Delete(key K) error {
+	i, ok := pq.index[key]
+
+	if !ok {
+		return fmt.Errorf("key %v is not in the priority queue", key)
+	}
+
+	pq.removeAt(i)
+
+	return nil
+}
+
+// PeekMin returns the key with the smallest priority together with its priority
+// without removing it. If the queue is empty, a non-nil error is returned.
+func (pq *IndexedPriorityQueue[K, P]) PeekMin() (K, P, error) {
+	if pq.IsEmpty() {
+		var k K
+		var p P
+		return k, p, errors.New("cannot peek into empty priority queue")
+	}
+
+	return pq.keys[0], pq.priorities[0], nil
+}
+
+// ExtractMin removes and returns the key with the smallest priority together with
+// its priority. If the queue is empty, a non-nil error is returned.
+func (pq *IndexedPriorityQueue[K, P]) ExtractMin() (K, P, error) {
+	if pq.IsEmpty() {
+		var k K
+		var p P
+		return k, p, errors.New("cannot extract from empty priority queue")
+	}
+
+	key, priority := pq.keys[0], pq.priorities[0]
+	pq.removeAt(0)
+
+	return key, priority, nil
+}
+
+// Size returns the number of keys in the queue.
+func (pq *IndexedPriorityQueue[K, P]) Size() int {
+	return pq.n
+}
+
+// IsEmpty returns true if the queue is empty, false otherwise.
+func (pq *IndexedPriorityQueue[K, P]) IsEmpty() bool {
+	return pq.n == 0
+}
+
+// removeAt removes the entry at heap position i and restores the heap order.
+func (pq *IndexedPriorityQueue[K, P]) removeAt(i int) {
+	delete(pq.index, pq.keys[i])
+	pq.n--
+
+	if i != pq.n {
+		pq.keys[i], pq.priorities[i] = pq.keys[pq.n], pq.priorities[pq.n]
+		pq.index[pq.keys[i]] = i
+	}
+
+	var k K
+	var p P
+	pq.keys[pq.n], pq.priorities[pq.n] = k, p
+
+	if i < pq.n {
+		pq.siftUp(i)
+		pq.siftDown(pq.index[pq.keys[i]])
+	}
+}
+
+// less returns true if the priority at position i is smaller than the one at position j.
+func (pq *IndexedPriorityQueue[K, P]) less(i, j int) bool {
+	return pq.compareFunc(pq.priorities[i], pq.priorities[j]) < 0
+}
+
+// swap exchanges the entries at positions i and j and updates the index.
+func (pq *IndexedPriorityQueue[K, P]) swap(i, j int) {
+	pq.keys[i], pq.keys[j] = pq.keys[j], pq.keys[i]
+	pq.priorities[i], pq.priorities[j] = pq.priorities[j], pq.priorities[i]
+	pq.index[pq.keys[i]] = i
+	pq.index[pq.keys[j]] = j
+}
+
+// siftUp moves the entry at position i up until its parent is not greater.
+func (pq *IndexedPriorityQueue[K, P]) siftUp(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+
+		if !pq.less(i, parent) {
+			return
+		}
+
+		pq.swap(i, parent)
+		i = parent
+	}
+}
+
+// siftDown moves the entry at position i down until none of its children is smaller.
+func (pq *IndexedPriorityQueue[K, P]) siftDown(i int) {
+	for {
+		smallest := i
+		left, right := 2*i+1, 2*i+2
+
+		if left < pq.n && pq.less(left, smallest) {
+			smallest = left
+		}
+
+		if right < pq.n && pq.less(right, smallest) {
+			smallest = right
+		}
+
+		if smallest == i {
+			return
+		}
+
+		pq.swap(i, smallest)
+		i = smallest
+	}
+}
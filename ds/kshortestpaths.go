@@ -0,0 +1,231 @@
+package ds
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Path is a path through a graph given by its vertices, together with its total
+// cost. Between two consecutive vertices the lightest edge is used.
+type Path struct {
+	Vertices []int
+	Cost     int
+}
+
+// Yen computes the k shortest simple paths from s to t in increasing order of cost
+// using Yen's algorithm. Every path after the first deviates from an earlier path at
+// a spur vertex: Dijkstra searches the rest of the path from there in a view of the
+// graph without the vertices of the shared root path and without the edges taken by
+// earlier paths with the same root. The candidates wait in a heap until they are
+// the cheapest. Fewer than k paths are returned if there are no more simple paths.
+// If s or t are not vertices of the graph, k is negative or the graph has an edge
+// with a negative weight, a non-nil error is returned.
+func Yen(g GraphView, s, t, k int) ([]Path, error) {
+	if err := validatePathEndpoints(g, s, t); err != nil {
+		return nil, err
+	}
+
+	if k < 0 {
+		return nil, errors.New("number of paths must be non-negative")
+	}
+
+	sp, err := Dijkstra(g, s)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := []Path{}
+
+	if k == 0 || !sp.HasPathTo(t) {
+		return paths, nil
+	}
+
+	first := newYenCandidate(nil, 0, sp, t)
+	accepted := []*yenCandidate{first}
+	seen := map[string]bool{fmt.Sprint(first.vertices): true}
+	candidates := NewMinHeap(0, compareYenCandidates)
+
+	for len(accepted) < k {
+		last := accepted[len(accepted)-1]
+
+		for i := 0; i < len(last.vertices)-1; i++ {
+			view := newYenView(g)
+			root := last.vertices[:i+1]
+
+			for _, v := range root[:i] {
+				view.removed[v] = true
+			}
+
+			for _, c := range accepted {
+				if len(c.vertices) > i+1 && equalInts(c.vertices[:i+1], root) {
+					view.blocked[[2]int{root[i], c.vertices[i+1]}] = true
+				}
+			}
+
+			spur, err := Dijkstra(view, root[i])
+			if err != nil {
+				return nil, err
+			}
+
+			if !spur.HasPathTo(t) {
+				continue
+			}
+
+			c := newYenCandidate(last, i, spur, t)
+
+			if key := fmt.Sprint(c.vertices); !seen[key] {
+				seen[key] = true
+				candidates.Insert(c)
+			}
+		}
+
+		c, err := candidates.Extract()
+		if err != nil {
+			break
+		}
+
+		accepted = append(accepted, c)
+	}
+
+	for _, c := range accepted {
+		paths = append(paths, Path{Vertices: c.vertices, Cost: c.prefix[len(c.prefix)-1]})
+	}
+
+	return paths, nil
+}
+
+// yenCandidate is a path found by Yen, with the cost of every prefix of the path.
+type yenCandidate struct {
+	vertices []int
+	prefix   []int
+}
+
+// newYenCandidate joins the first i vertices of the path last with the path from
+// the root of the shortest path tree to t. The tree must be rooted at vertex i of
+// last. If last is nil, the path starts at the root of the tree.
+func newYenCandidate(last *yenCandidate, i int, sp *ShortestPaths, t int) *yenCandidate {
+	c := &yenCandidate{}
+	offset := 0
+
+	if last != nil {
+		c.vertices = append(c.vertices, last.vertices[:i]...)
+		c.prefix = append(c.prefix, last.prefix[:i]...)
+		offset = last.prefix[i]
+	}
+
+	for _, v := range sp.PathTo(t) {
+		c.vertices = append(c.vertices, v)
+		c.prefix = append(c.prefix, offset+sp.dist[v])
+	}
+
+	return c
+}
+
+// compareYenCandidates orders candidates by cost, then by number of vertices and
+// then lexicographically, which makes the result deterministic.
+func compareYenCandidates(a, b *yenCandidate) int {
+	if c := compareInts(a.prefix[len(a.prefix)-1], b.prefix[len(b.prefix)-1]); c != 0 {
+		return c
+	}
+
+	if c := compareInts(len(a.vertices), len(b.vertices)); c != 0 {
+		return c
+	}
+
+	for i := range a.vertices {
+		if c := compareInts(a.vertices[i], b.vertices[i]); c != 0 {
+			return c
+		}
+	}
+
+	return 0
+}
+
+// equalInts returns true if both slices hold the same integers.
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// yenView is a view of a graph without some vertices and edges.
+type yenView struct {
+	g       GraphView
+	removed []bool
+	blocked map[[2]int]bool
+}
+
+// newYenView returns a view of the whole graph.
+func newYenView(g GraphView) *yenView {
+	return &yenView{g: g, removed: make([]bool, g.V()), blocked: map[[2]int]bool{}}
+}
+
+// V returns the number of vertices, including the removed ones.
+func (view *yenView) V() int {
+	return view.g.V()
+}
+
+// Directed returns true if the graph is directed.
+func (view *yenView) Directed() bool {
+	return view.g.Directed()
+}
+
+// OutEdges returns an iterator over the edges leaving vertex x that neither lead to
+// a removed vertex nor are blocked. A removed vertex has no edges.
+func (view *yenView) OutEdges(x int) Iterator[Edge] {
+	it := &yenEdgeIterator{view: view, edges: newEdgeCursor(view.g.OutEdges(x))}
+
+	if view.removed[x] {
+		it.edges.valid = false
+	}
+
+	it.skip()
+
+	return it
+}
+
+// yenEdgeIterator iterates over the edges of a yenView.
+type yenEdgeIterator struct {
+	view  *yenView
+	edges edgeCursor
+}
+
+// skip advances past the edges hidden by the view.
+func (it *yenEdgeIterator) skip() {
+	for it.edges.valid {
+		e := it.edges.edge
+
+		if !it.view.removed[e.To] && !it.view.blocked[[2]int{e.From, e.To}] {
+			return
+		}
+
+		it.edges.advance()
+	}
+}
+
+// GetNext returns the next edge. If there are no more edges, a non-nil error is
+// returned.
+func (it *yenEdgeIterator) GetNext() (Edge, error) {
+	if !it.edges.valid {
+		return Edge{}, errors.New("no more edges")
+	}
+
+	e := it.edges.edge
+	it.edges.advance()
+	it.skip()
+
+	return e, nil
+}
+
+// HasNext returns true if there are more edges.
+func (it *yenEdgeIterator) HasNext() bool {
+	return it.edges.valid
+}
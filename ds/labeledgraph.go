@@ -0,0 +1,355 @@
+package ds
+
+import (
+	"errors"
+	"fmt"
+)
+
+// labeledVertex is an adjacency entry of a LabeledGraph.
+type labeledVertex[W Number] struct {
+	y      int
+	weight W
+	next   *labeledVertex[W]
+}
+
+// LabeledEdge is a weighted edge of a LabeledGraph between vertex labels.
+type LabeledEdge[V comparable, W Number] struct {
+	From   V
+	To     V
+	Weight W
+}
+
+// LabeledGraph is a graph whose vertices are identified by labels of any comparable
+// type, e.g. strings or structs, and whose edges have weights of any numeric type.
+// Vertices are added on demand and numbered in the order of their addition, which
+// is the numbering used by View and ToGraph. The algorithms on GraphView work with
+// integer weights: View converts the weights with a given function, e.g. rounding to
+// cents, and ToGraph copies graphs whose weights are all integers.
+type LabeledGraph[V comparable, W Number] struct {
+	labels   []V
+	index    map[V]int
+	adj      []*labeledVertex[W]
+	e        int
+	directed bool
+}
+
+// NewLabeledGraph creates a new labeled graph without vertices.
+func NewLabeledGraph[V comparable, W Number](directed bool) *LabeledGraph[V, W] {
+	return &LabeledGraph[V, W]{index: map[V]int{}, directed: directed}
+}
+
+// V returns the number of vertices in the graph.
+func (g *LabeledGraph[V, W]) V() int {
+	return len(g.labels)
+}
+
+// E returns the number of edges in the graph. Like Graph.E, every undirected edge
+// is counted twice.
+func (g *LabeledGraph[V, W]) E() int {
+	return g.e
+}
+
+// Directed returns true if the graph is directed, false otherwise.
+func (g *LabeledGraph[V, W]) Directed() bool {
+	return g.directed
+}
+
+// AddVertex adds a vertex with the given label unless it exists, and returns its
+// number.
+func (g *LabeledGraph[V, W]) AddVertex(label V) int {
+	if i, ok := g.index[label]; ok {
+		return i
+	}
+
+	g.index[label] = len(g.labels)
+	g.labels = append(g.labels, label)
+	g.adj = append(g.adj, nil)
+
+	return len(g.labels) - 1
+}
+
+// HasVertex returns true if the graph has a vertex with the given label.
+func (g *LabeledGraph[V, W]) HasVertex(label V) bool {
+	_, ok := g.index[label]
+	return ok
+}
+
+// Index returns the number of the vertex with the given label, and false if there
+// is no such vertex.
+func (g *LabeledGraph[V, W]) Index(label V) (int, bool) {
+	i, ok := g.index[label]
+	return i, ok
+}
+
+// Label returns the label of the vertex with the given number. If there is no such
+// vertex, a non-nil error is returned.
+func (g *LabeledGraph[V, W]) Label(i int) (V, error) {
+	if i < 0 || i >= len(g.labels) {
+		var zero V
+		return zero, fmt.Errorf("vertex %d is out of bounds for a graph with %d vertices", i, len(g.labels))
+	}
+	return g.labels[i], nil
+}
+
+// Labels returns the labels of all vertices in the order of their numbers.
+func (g *LabeledGraph[V, W]) Labels() []V {
+	return append([]V{}, g.labels...)
+}
+
+// AddEdge adds an edge between the vertices with labels x and y with the given
+// weight, adding the vertices if they do not exist yet.
+func (g *LabeledGraph[V, W]) AddEdge(x, y V, weight W) {
+	i, j := g.AddVertex(x), g.AddVertex(y)
+
+	g.adj[i] = &labeledVertex[W]{j, weight, g.adj[i]}
+	g.e++
+
+	if !g.directed {
+		g.adj[j] = &labeledVertex[W]{i, weight, g.adj[j]}
+		g.e++
+	}
+}
+
+// Neighbors returns the edges leaving the vertex with the given label, most
+// recently added first. If there is no such vertex, nil is returned.
+func (g *LabeledGraph[V, W]) Neighbors(label V) []LabeledEdge[V, W] {
+	i, ok := g.index[label]
+	if !ok {
+		return nil
+	}
+
+	edges := []LabeledEdge[V, W]{}
+
+	for e := g.adj[i]; e != nil; e = e.next {
+		edges = append(edges, LabeledEdge[V, W]{label, g.labels[e.y], e.weight})
+	}
+
+	return edges
+}
+
+// Edges returns all edges of the graph. Each edge of an undirected graph is
+// returned only once.
+func (g *LabeledGraph[V, W]) Edges() []LabeledEdge[V, W] {
+	edges := make([]LabeledEdge[V, W], 0, g.e)
+
+	for x := range g.adj {
+		selfLoop := false
+
+		for v := g.adj[x]; v != nil; v = v.next {
+			if g.directed || firstUndirectedEntry(x, v.y, &selfLoop) {
+				edges = append(edges, LabeledEdge[V, W]{g.labels[x], g.labels[v.y], v.weight})
+			}
+		}
+	}
+
+	return edges
+}
+
+// View returns a GraphView of the graph with the same vertex numbers, whose edge
+// weights are converted by the given function, so that all algorithms on GraphView
+// can be used, also with fractional weights. The view reflects later changes of the
+// graph. Results map back to labels with Label.
+func (g *LabeledGraph[V, W]) View(weight func(w W) int) GraphView {
+	return &labeledView[V, W]{g, weight}
+}
+
+// labeledView is a GraphView of a LabeledGraph.
+type labeledView[V comparable, W Number] struct {
+	g      *LabeledGraph[V, W]
+	weight func(w W) int
+}
+
+// V returns the number of vertices.
+func (view *labeledView[V, W]) V() int {
+	return len(view.g.labels)
+}
+
+// Directed returns true if the graph is directed.
+func (view *labeledView[V, W]) Directed() bool {
+	return view.g.directed
+}
+
+// OutEdges returns an iterator over the edges leaving vertex x with converted weights.
+func (view *labeledView[V, W]) OutEdges(x int) Iterator[Edge] {
+	return &labeledEdgeIterator[W]{x, view.g.adj[x], view.weight}
+}
+
+// labeledEdgeIterator iterates over an adjacency list of a LabeledGraph.
+type labeledEdgeIterator[W Number] struct {
+	x      int
+	next   *labeledVertex[W]
+	weight func(w W) int
+}
+
+// GetNext returns the next edge. If there are no more edges, a non-nil error is
+// returned.
+func (it *labeledEdgeIterator[W]) GetNext() (Edge, error) {
+	if it.next == nil {
+		return Edge{}, errors.New("no more edges")
+	}
+
+	e := Edge{it.x, it.next.y, it.weight(it.next.weight)}
+	it.next = it.next.next
+
+	return e, nil
+}
+
+// HasNext returns true if there are more edges.
+func (it *labeledEdgeIterator[W]) HasNext() bool {
+	return it.next != nil
+}
+
+// ToGraph returns a Graph with the same vertex numbers and adjacency lists, so that
+// all algorithms on Graph can be used. Results map back to labels with Label. If a
+// weight is not an integer or does not fit into an int, a non-nil error is
+// returned; View converts such weights instead.
+func (g *LabeledGraph[V, W]) ToGraph() (*Graph, error) {
+	graph := NewGraph(len(g.labels), g.directed)
+	var zero W
+
+	for x := range g.adj {
+		var tail *Vertex
+
+		for v := g.adj[x]; v != nil; v = v.next {
+			weight := int(v.weight)
+
+			if W(weight) != v.weight || (weight < 0) != (v.weight < zero) {
+				return nil, fmt.Errorf("edge %v - %v has weight %v, which is not an int", g.labels[x], g.labels[v.y], v.weight)
+			}
+
+			entry := &Vertex{v.y, weight, nil}
+
+			if tail == nil {
+				graph.adj[x] = entry
+			} else {
+				tail.next = entry
+			}
+			tail = entry
+		}
+	}
+
+	graph.e = g.e
+
+	return graph, nil
+}
+
+// LabeledShortestPaths is a shortest path tree of a LabeledGraph, as computed by
+// LabeledDijkstra.
+type LabeledShortestPaths[V comparable, W Number] struct {
+	g       *LabeledGraph[V, W]
+	source  int
+	dist    []W
+	parent  []int
+	reached []bool
+}
+
+// Source returns the label of the source vertex.
+func (sp *LabeledShortestPaths[V, W]) Source() V {
+	return sp.g.labels[sp.source]
+}
+
+// HasPathTo returns true if there is a path from the source to the given vertex.
+func (sp *LabeledShortestPaths[V, W]) HasPathTo(label V) bool {
+	i, ok := sp.g.index[label]
+	return ok && i < len(sp.reached) && sp.reached[i]
+}
+
+// DistTo returns the length of the shortest path from the source to the given
+// vertex, and false if there is no such path.
+func (sp *LabeledShortestPaths[V, W]) DistTo(label V) (W, bool) {
+	if !sp.HasPathTo(label) {
+		var zero W
+		return zero, false
+	}
+	return sp.dist[sp.g.index[label]], true
+}
+
+// PathTo returns the labels of the vertices on a shortest path from the source to
+// the given vertex. If there is no such path, nil is returned.
+func (sp *LabeledShortestPaths[V, W]) PathTo(label V) []V {
+	if !sp.HasPathTo(label) {
+		return nil
+	}
+
+	indices := []int{}
+
+	for x := sp.g.index[label]; x != -1; x = sp.parent[x] {
+		indices = append(indices, x)
+	}
+
+	reverseInts(indices)
+
+	path := make([]V, len(indices))
+	for i, x := range indices {
+		path[i] = sp.g.labels[x]
+	}
+
+	return path
+}
+
+// LabeledDijkstra computes the shortest paths from the vertex with label s using
+// Dijkstra's algorithm in O(E log V). Paths to vertices added to the graph later are
+// not reported. Paths whose length overflows W are ignored. If s is not a vertex of
+// the graph or the graph has an edge whose weight is negative or NaN, a non-nil
+// error is returned.
+func LabeledDijkstra[V comparable, W Number](g *LabeledGraph[V, W], s V) (*LabeledShortestPaths[V, W], error) {
+	source, ok := g.index[s]
+	if !ok {
+		return nil, fmt.Errorf("vertex %v is not in the graph", s)
+	}
+
+	var zero W
+
+	for x := range g.adj {
+		for e := g.adj[x]; e != nil; e = e.next {
+			if e.weight < zero {
+				return nil, fmt.Errorf("edge %v -> %v has negative weight %v", g.labels[x], g.labels[e.y], e.weight)
+			}
+
+			// NaN is the only value that differs from itself
+			if e.weight != e.weight {
+				return nil, fmt.Errorf("edge %v -> %v has weight NaN", g.labels[x], g.labels[e.y])
+			}
+		}
+	}
+
+	v := len(g.labels)
+	sp := &LabeledShortestPaths[V, W]{g: g, source: source, dist: make([]W, v), parent: make([]int, v), reached: make([]bool, v)}
+	settled := make([]bool, v)
+
+	for i := range sp.parent {
+		sp.parent[i] = -1
+	}
+
+	sp.reached[source] = true
+	pq := NewIndexedPriorityQueue[int, W](0, compareNumbers[W])
+	pq.Insert(source, zero)
+
+	for !pq.IsEmpty() {
+		x, _, _ := pq.ExtractMin()
+		settled[x] = true
+
+		for e := g.adj[x]; e != nil; e = e.next {
+			d := sp.dist[x] + e.weight
+
+			// the distances of settled vertices are final, and a sum below the
+			// distance of x means that an integer weight overflowed
+			if settled[e.y] || d < sp.dist[x] || (sp.reached[e.y] && d >= sp.dist[e.y]) {
+				continue
+			}
+
+			sp.dist[e.y] = d
+			sp.parent[e.y] = x
+
+			if pq.Contains(e.y) {
+				pq.DecreaseKey(e.y, d)
+			} else if !sp.reached[e.y] {
+				pq.Insert(e.y, d)
+			}
+
+			sp.reached[e.y] = true
+		}
+	}
+
+	return sp, nil
+}
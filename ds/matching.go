@@ -0,0 +1,208 @@
+package ds
+
+import "fmt"
+
+// Matching is a set of edges of which no two share a vertex, as computed by
+// HopcroftKarp.
+type Matching struct {
+	mate []int
+	size int
+}
+
+// newMatching returns an empty matching for v vertices.
+func newMatching(v int) *Matching {
+	m := &Matching{mate: make([]int, v)}
+
+	for i := range m.mate {
+		m.mate[i] = -1
+	}
+
+	return m
+}
+
+// Size returns the number of matched edges.
+func (m *Matching) Size() int {
+	return m.size
+}
+
+// Mate returns the vertex matched to v, or -1 if v is unmatched or not a vertex of
+// the graph.
+func (m *Matching) Mate(v int) int {
+	if v < 0 || v >= len(m.mate) {
+		return -1
+	}
+	return m.mate[v]
+}
+
+// IsMatched returns true if v is matched.
+func (m *Matching) IsMatched(v int) bool {
+	return m.Mate(v) != -1
+}
+
+// IsPerfect returns true if every vertex is matched.
+func (m *Matching) IsPerfect() bool {
+	return 2*m.size == len(m.mate)
+}
+
+// Pairs returns the matched pairs of vertices with the smaller vertex first, in
+// increasing order.
+func (m *Matching) Pairs() [][2]int {
+	pairs := make([][2]int, 0, m.size)
+
+	for x, y := range m.mate {
+		if x < y {
+			pairs = append(pairs, [2]int{x, y})
+		}
+	}
+
+	return pairs
+}
+
+// match adds the edge between x and y to the matching, replacing the previous mates.
+func (m *Matching) match(x, y int) {
+	m.mate[x] = y
+	m.mate[y] = x
+}
+
+// HopcroftKarp computes a maximum cardinality matching of a bipartite undirected
+// graph using the Hopcroft-Karp algorithm in O(E sqrt(V)). Each phase finds a
+// maximal set of shortest augmenting paths: a breadth first search from all free
+// vertices of color 0 builds a layered graph, which an iterative depth first search
+// then explores. If the graph is directed or not bipartite, a non-nil error is
+// returned.
+func HopcroftKarp(g GraphView) (*Matching, error) {
+	b, err := Bipartite(g)
+	if err != nil {
+		return nil, err
+	}
+
+	if !b.IsBipartite() {
+		return nil, fmt.Errorf("graph is not bipartite, it has an odd cycle: %v", b.oddCycle)
+	}
+
+	left, _ := b.Sides()
+	m := newMatching(g.V())
+	dist := make([]int, g.V())
+	current := make([]edgeCursor, g.V())
+
+	for {
+		limit := m.layers(g, left, dist)
+		if limit == -1 {
+			break
+		}
+
+		for _, x := range left {
+			current[x] = newEdgeCursor(g.OutEdges(x))
+		}
+
+		for _, u := range left {
+			if m.mate[u] == -1 && m.augment(u, limit, dist, current) {
+				m.size++
+			}
+		}
+	}
+
+	return m, nil
+}
+
+// layers runs a breadth first search from all free vertices of the left side along
+// alternating paths, storing the distance of every left vertex in dist. It returns
+// the distance of the left vertices adjacent to a free right vertex, i.e. the length
+// of the shortest augmenting paths, or -1 if there is none.
+func (m *Matching) layers(g GraphView, left []int, dist []int) int {
+	queue := []int{}
+
+	for _, x := range left {
+		if m.mate[x] == -1 {
+			dist[x] = 0
+			queue = append(queue, x)
+		} else {
+			dist[x] = INFINITE_DISTANCE
+		}
+	}
+
+	limit := -1
+
+	for head := 0; head < len(queue); head++ {
+		x := queue[head]
+
+		if limit != -1 && dist[x] >= limit {
+			break
+		}
+
+		for it := g.OutEdges(x); it.HasNext(); {
+			w := m.mate[nextEdge(it).To]
+
+			if w == -1 {
+				if limit == -1 {
+					limit = dist[x]
+				}
+			} else if dist[w] == INFINITE_DISTANCE {
+				dist[w] = dist[x] + 1
+				queue = append(queue, w)
+			}
+		}
+	}
+
+	return limit
+}
+
+// augment searches a shortest augmenting path from the free left vertex u through
+// the layered graph with an explicit stack of left vertices, and flips it if found.
+// current holds the next edge to examine for every left vertex, and vertices without
+// a path to a free vertex are removed from the layered graph.
+func (m *Matching) augment(u, limit int, dist []int, current []edgeCursor) bool {
+	stack := []int{u}
+
+	for len(stack) > 0 {
+		x := stack[len(stack)-1]
+
+		if !current[x].valid {
+			dist[x] = INFINITE_DISTANCE
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		w := m.mate[current[x].edge.To]
+
+		if w == -1 && dist[x] == limit {
+			// every vertex on the stack takes the right vertex its current edge leads to
+			for _, v := range stack {
+				m.match(v, current[v].edge.To)
+			}
+			return true
+		}
+
+		if w != -1 && dist[w] == dist[x]+1 {
+			stack = append(stack, w)
+			continue
+		}
+
+		current[x].advance()
+	}
+
+	return false
+}
+
+// edgeCursor walks an edge iterator while keeping the current edge at hand.
+type edgeCursor struct {
+	edges Iterator[Edge]
+	edge  Edge
+	valid bool
+}
+
+// newEdgeCursor returns a cursor positioned at the first edge of the iterator.
+func newEdgeCursor(edges Iterator[Edge]) edgeCursor {
+	c := edgeCursor{edges: edges}
+	c.advance()
+	return c
+}
+
+// advance moves the cursor to the next edge, and invalidates it if there is none.
+func (c *edgeCursor) advance() {
+	c.valid = c.edges.HasNext()
+
+	if c.valid {
+		c.edge = nextEdge(c.edges)
+	}
+}
@@ -0,0 +1,234 @@
+package ds
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// MatrixGraph is a graph stored as an adjacency matrix, which answers HasEdge and
+// Weight in O(1) but needs O(V^2) memory, so it suits small dense graphs. There is
+// at most one edge from x to y: adding an existing edge replaces its weight.
+// MatrixGraph implements GraphView.
+type MatrixGraph struct {
+	v        int
+	e        int
+	weights  []int
+	present  []bool
+	directed bool
+}
+
+// NewMatrixGraph creates a new graph with v vertices and no edges. If v is negative
+// or the matrix would have more than math.MaxInt entries, a non-nil error is
+// returned.
+func NewMatrixGraph(v int, directed bool) (*MatrixGraph, error) {
+	if v < 0 {
+		return nil, errors.New("number of vertices must be non-negative")
+	}
+
+	if v > 0 && v > math.MaxInt/v {
+		return nil, fmt.Errorf("an adjacency matrix of %d vertices has too many entries", v)
+	}
+
+	return &MatrixGraph{v: v, weights: make([]int, v*v), present: make([]bool, v*v), directed: directed}, nil
+}
+
+// NewMatrixGraphFromGraph returns an adjacency matrix copy of the given graph. Of
+// parallel edges only the lightest one is kept. If the graph has too many vertices
+// for an adjacency matrix, a non-nil error is returned.
+func NewMatrixGraphFromGraph(g *Graph) (*MatrixGraph, error) {
+	m, err := NewMatrixGraph(g.v, g.directed)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range g.Edges() {
+		if w, err := m.Weight(e.From, e.To); err != nil || e.Weight < w {
+			m.AddEdge(e.From, e.To, e.Weight)
+		}
+	}
+
+	return m, nil
+}
+
+// V returns the number of vertices in the graph.
+func (m *MatrixGraph) V() int {
+	return m.v
+}
+
+// E returns the number of edges in the graph. Like Graph.E, every undirected edge
+// is counted twice.
+func (m *MatrixGraph) E() int {
+	return m.e
+}
+
+// Directed returns true if the graph is directed, false otherwise.
+func (m *MatrixGraph) Directed() bool {
+	return m.directed
+}
+
+// AddEdge adds an edge between vertices x and y with the given weight, or replaces
+// the weight of an existing edge. If x or y are not vertices of the graph, a non-nil
+// error is returned.
+func (m *MatrixGraph) AddEdge(x, y, weight int) error {
+	if err := m.validateEdge(x, y); err != nil {
+		return err
+	}
+
+	if !m.present[x*m.v+y] {
+		m.e += m.entries()
+	}
+
+	m.set(x, y, weight, true)
+
+	return nil
+}
+
+// RemoveEdge removes the edge between vertices x and y. If x or y are not vertices
+// of the graph or there is no such edge, a non-nil error is returned.
+func (m *MatrixGraph) RemoveEdge(x, y int) error {
+	if !m.HasEdge(x, y) {
+		if err := m.validateEdge(x, y); err != nil {
+			return err
+		}
+		return fmt.Errorf("there is no edge between %d and %d", x, y)
+	}
+
+	m.e -= m.entries()
+	m.set(x, y, 0, false)
+
+	return nil
+}
+
+// HasEdge returns true if there is an edge between vertices x and y.
+func (m *MatrixGraph) HasEdge(x, y int) bool {
+	return m.validateEdge(x, y) == nil && m.present[x*m.v+y]
+}
+
+// Weight returns the weight of the edge between vertices x and y. If x or y are not
+// vertices of the graph or there is no such edge, a non-nil error is returned.
+func (m *MatrixGraph) Weight(x, y int) (int, error) {
+	if err := m.validateEdge(x, y); err != nil {
+		return 0, err
+	}
+
+	if !m.present[x*m.v+y] {
+		return 0, fmt.Errorf("there is no edge between %d and %d", x, y)
+	}
+
+	return m.weights[x*m.v+y], nil
+}
+
+// Degree returns the number of edges leaving vertex x in O(V). A self-loop of an
+// undirected graph counts twice. If x is not a vertex of the graph, a non-nil error
+// is returned.
+func (m *MatrixGraph) Degree(x int) (int, error) {
+	if err := validateViewVertex(m, x); err != nil {
+		return 0, err
+	}
+
+	degree := 0
+	for it := m.OutEdges(x); it.HasNext(); nextEdge(it) {
+		degree++
+	}
+
+	return degree, nil
+}
+
+// OutEdges returns an iterator over the edges leaving vertex x in increasing order
+// of their targets, which must be a vertex of the graph. A self-loop of an undirected
+// graph is reported twice, like in Graph.
+func (m *MatrixGraph) OutEdges(x int) Iterator[Edge] {
+	it := &matrixEdgeIterator{m: m, x: x, y: -1}
+	it.advance()
+	return it
+}
+
+// ToGraph returns a Graph with the same edges. The adjacency list of every vertex
+// lists its edges in increasing order of their targets.
+func (m *MatrixGraph) ToGraph() *Graph {
+	g := NewGraph(m.v, m.directed)
+
+	for x := m.v - 1; x >= 0; x-- {
+		for y := m.v - 1; y >= 0; y-- {
+			if m.present[x*m.v+y] {
+				g.adj[x] = &Vertex{y, m.weights[x*m.v+y], g.adj[x]}
+				g.e++
+
+				if !m.directed && x == y {
+					g.adj[x] = &Vertex{y, m.weights[x*m.v+y], g.adj[x]}
+					g.e++
+				}
+			}
+		}
+	}
+
+	return g
+}
+
+// entries returns the number of entries counted by E for a single edge.
+func (m *MatrixGraph) entries() int {
+	if m.directed {
+		return 1
+	}
+	return 2
+}
+
+// set sets the matrix entry of x and y, and of y and x for undirected graphs.
+func (m *MatrixGraph) set(x, y, weight int, present bool) {
+	m.weights[x*m.v+y], m.present[x*m.v+y] = weight, present
+
+	if !m.directed {
+		m.weights[y*m.v+x], m.present[y*m.v+x] = weight, present
+	}
+}
+
+// validateEdge returns a non-nil error if x or y are not vertices of the graph.
+func (m *MatrixGraph) validateEdge(x, y int) error {
+	if err := validateViewVertex(m, x); err != nil {
+		return err
+	}
+	return validateViewVertex(m, y)
+}
+
+// matrixEdgeIterator iterates over a row of the adjacency matrix.
+type matrixEdgeIterator struct {
+	m      *MatrixGraph
+	x      int
+	y      int
+	repeat bool
+}
+
+// advance moves to the next edge of the row, repeating undirected self-loops.
+func (it *matrixEdgeIterator) advance() {
+	if !it.m.directed && it.y == it.x && !it.repeat {
+		it.repeat = true
+		return
+	}
+
+	row := it.m.present[it.x*it.m.v : (it.x+1)*it.m.v]
+
+	for it.y++; it.y < it.m.v; it.y++ {
+		if row[it.y] {
+			return
+		}
+	}
+}
+
+// GetNext returns the next edge. If there are no more edges, a non-nil error is
+// returned.
+func (it *matrixEdgeIterator) GetNext() (Edge, error) {
+	if !it.HasNext() {
+		return Edge{}, errors.New("no more edges")
+	}
+
+	e := Edge{it.x, it.y, it.m.weights[it.x*it.m.v+it.y]}
+	it.advance()
+
+	return e, nil
+}
+
+// HasNext returns true if there are more edges.
+func (it *matrixEdgeIterator) HasNext() bool {
+	return it.y < it.m.v
+}
@@ -0,0 +1,173 @@
+package ds
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// WriteMatrixMarket writes the adjacency matrix of the graph in the Matrix Market
+// coordinate format with integer entries, numbering vertices from 1. Directed
+// graphs are written as general matrices with one entry "x y w" per edge from x to
+// y. Undirected graphs are written as symmetric matrices with one entry per edge in
+// the lower triangle. Parallel edges are written as repeated entries. It returns the
+// first error of the writer.
+func (g *Graph) WriteMatrixMarket(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	if g.directed {
+		fmt.Fprintln(bw, "%%MatrixMarket matrix coordinate integer general")
+		fmt.Fprintf(bw, "%d %d %d\n", g.v, g.v, g.e)
+
+		for x := 0; x < g.v; x++ {
+			for e := g.adj[x]; e != nil; e = e.next {
+				fmt.Fprintf(bw, "%d %d %d\n", x+1, e.y+1, e.weight)
+			}
+		}
+	} else {
+		edges := g.Edges()
+
+		fmt.Fprintln(bw, "%%MatrixMarket matrix coordinate integer symmetric")
+		fmt.Fprintf(bw, "%d %d %d\n", g.v, g.v, len(edges))
+
+		for _, e := range edges {
+			fmt.Fprintf(bw, "%d %d %d\n", e.To+1, e.From+1, e.Weight)
+		}
+	}
+
+	return bw.Flush()
+}
+
+// ReadMatrixMarket reads a graph from its adjacency matrix in the Matrix Market
+// coordinate format. The header "%%MatrixMarket matrix coordinate FIELD SYMMETRY"
+// determines the weights and the type of the graph: integer and real entries are
+// edge weights, where real entries must be integral, and pattern entries have
+// weight 1. General matrices yield directed graphs, symmetric matrices undirected
+// graphs. The size line "N N NNZ" must describe a square matrix with at most
+// MAX_READ_VERTICES rows. The input is parsed line by line. If it is malformed, a
+// non-nil error naming the offending line is returned.
+func ReadMatrixMarket(r io.Reader) (*Graph, error) {
+	lr := newLineReader(r)
+
+	line, ok, err := lr.next()
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("line %d: missing header \"%%%%MatrixMarket matrix coordinate FIELD SYMMETRY\"", lr.number+1)
+	}
+
+	header := strings.Fields(strings.ToLower(line))
+
+	if len(header) != 5 || header[0] != "%%matrixmarket" || header[1] != "matrix" || header[2] != "coordinate" {
+		return nil, fmt.Errorf("line %d: expected header \"%%%%MatrixMarket matrix coordinate FIELD SYMMETRY\"", lr.number)
+	}
+
+	field, symmetry := header[3], header[4]
+
+	if field != "integer" && field != "real" && field != "pattern" {
+		return nil, fmt.Errorf("line %d: unsupported field %q, expected integer, real or pattern", lr.number, field)
+	}
+
+	if symmetry != "general" && symmetry != "symmetric" {
+		return nil, fmt.Errorf("line %d: unsupported symmetry %q, expected general or symmetric", lr.number, symmetry)
+	}
+
+	var g *Graph
+	entries, count := 0, 0
+
+	for {
+		line, ok, err := lr.next()
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			break
+		}
+
+		if line[0] == '%' {
+			continue
+		}
+
+		if g == nil {
+			size, err := parseInts(line, 3)
+			if err != nil || size[0] < 0 || size[2] < 0 || size[0] != size[1] {
+				return nil, fmt.Errorf("line %d: invalid size %q, expected \"N N NNZ\" of a square matrix", lr.number, line)
+			}
+
+			if err := validateVertexCount(size[0]); err != nil {
+				return nil, fmt.Errorf("line %d: %v", lr.number, err)
+			}
+
+			g = NewGraph(size[0], symmetry == "general")
+			entries = size[2]
+			continue
+		}
+
+		x, y, weight, err := parseMatrixMarketEntry(line, field)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", lr.number, err)
+		}
+
+		if err := g.AddEdge(x-1, y-1, weight); err != nil {
+			return nil, fmt.Errorf("line %d: %v", lr.number, err)
+		}
+		count++
+	}
+
+	if g == nil {
+		return nil, fmt.Errorf("line %d: missing size line \"N N NNZ\"", lr.number+1)
+	}
+
+	if count != entries {
+		return nil, fmt.Errorf("line %d: expected %d entries, got %d", lr.number+1, entries, count)
+	}
+
+	return g, nil
+}
+
+// parseMatrixMarketEntry parses an entry "i j [value]" of the given field.
+func parseMatrixMarketEntry(line, field string) (int, int, int, error) {
+	fields := strings.Fields(line)
+	expected := 3
+
+	if field == "pattern" {
+		expected = 2
+	}
+
+	if len(fields) != expected {
+		return 0, 0, 0, fmt.Errorf("invalid entry %q, expected %d values", line, expected)
+	}
+
+	position, err := parseInts(strings.Join(fields[:2], " "), 2)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("invalid entry %q: %v", line, err)
+	}
+
+	weight := 1
+
+	switch field {
+	case "integer":
+		weight, err = strconv.Atoi(fields[2])
+	case "real":
+		var value float64
+
+		value, err = strconv.ParseFloat(fields[2], 64)
+		if err == nil && (value != math.Trunc(value) || math.Abs(value) > 1<<53) {
+			err = fmt.Errorf("%s is not an integral weight", fields[2])
+		}
+
+		weight = int(value)
+	}
+
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("invalid entry %q: %v", line, err)
+	}
+
+	return position[0], position[1], weight, nil
+}
@@ -0,0 +1,265 @@
+package ds
+
+import "errors"
+
+// MaxFlow is a maximum flow from a source to a sink, as computed by EdmondsKarp
+// and Dinic, together with a minimum cut.
+type MaxFlow struct {
+	value int
+	edges []FlowEdge
+	cut   []bool
+}
+
+// newMaxFlow collects the result from a network carrying a maximum flow.
+func newMaxFlow(fn *FlowNetwork, s, value int) *MaxFlow {
+	return &MaxFlow{value: value, edges: fn.Edges(), cut: fn.reachable(s)}
+}
+
+// Value returns the value of the flow, which equals the capacity of a minimum cut.
+func (mf *MaxFlow) Value() int {
+	return mf.value
+}
+
+// Edges returns the flow on every edge. Undirected edges of the graph are reported
+// as two opposite edges.
+func (mf *MaxFlow) Edges() []FlowEdge {
+	return append([]FlowEdge{}, mf.edges...)
+}
+
+// Flow returns the total flow on the edges from x to y.
+func (mf *MaxFlow) Flow(x, y int) int {
+	flow := 0
+
+	for _, e := range mf.edges {
+		if e.From == x && e.To == y {
+			flow += e.Flow
+		}
+	}
+
+	return flow
+}
+
+// MinCut returns the vertices on the source side of a minimum cut in increasing
+// order, i.e. the vertices reachable from the source in the residual network.
+func (mf *MaxFlow) MinCut() []int {
+	vertices := []int{}
+
+	for v, ok := range mf.cut {
+		if ok {
+			vertices = append(vertices, v)
+		}
+	}
+
+	return vertices
+}
+
+// InCut returns true if the given vertex is on the source side of the minimum cut.
+func (mf *MaxFlow) InCut(v int) bool {
+	return v >= 0 && v < len(mf.cut) && mf.cut[v]
+}
+
+// CutEdges returns the edges leading from the source side to the sink side of the
+// minimum cut. Their capacities add up to the value of the flow.
+func (mf *MaxFlow) CutEdges() []FlowEdge {
+	edges := []FlowEdge{}
+
+	for _, e := range mf.edges {
+		if mf.cut[e.From] && !mf.cut[e.To] {
+			edges = append(edges, e)
+		}
+	}
+
+	return edges
+}
+
+// validateFlowEndpoints returns a non-nil error if s and t are not two distinct
+// vertices of the graph.
+func validateFlowEndpoints(g GraphView, s, t int) error {
+	if err := validateViewVertex(g, s); err != nil {
+		return err
+	}
+
+	if err := validateViewVertex(g, t); err != nil {
+		return err
+	}
+
+	if s == t {
+		return errors.New("source and sink must be different vertices")
+	}
+
+	return nil
+}
+
+// EdmondsKarp computes a maximum flow from s to t using the edge weights as
+// capacities. It augments along shortest paths found by breadth first search in
+// O(VE^2). If s or t are invalid or a capacity is negative, a non-nil error is
+// returned.
+func EdmondsKarp(g GraphView, s, t int) (*MaxFlow, error) {
+	if err := validateFlowEndpoints(g, s, t); err != nil {
+		return nil, err
+	}
+
+	fn, err := NewFlowNetworkFromGraph(g, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	value := 0
+	parentArc := make([]int, g.V())
+
+	for {
+		for i := range parentArc {
+			parentArc[i] = -1
+		}
+
+		queue := []int{s}
+
+		for head := 0; head < len(queue) && parentArc[t] == -1; head++ {
+			x := queue[head]
+
+			for a := fn.head[x]; a != -1; a = fn.next[a] {
+				if y := fn.to[a]; y != s && parentArc[y] == -1 && fn.residual(a) > 0 {
+					parentArc[y] = a
+					queue = append(queue, y)
+				}
+			}
+		}
+
+		if parentArc[t] == -1 {
+			break
+		}
+
+		bottleneck := INFINITE_DISTANCE
+		for v := t; v != s; v = fn.from(parentArc[v]) {
+			if r := fn.residual(parentArc[v]); r < bottleneck {
+				bottleneck = r
+			}
+		}
+
+		for v := t; v != s; v = fn.from(parentArc[v]) {
+			fn.push(parentArc[v], bottleneck)
+		}
+
+		value += bottleneck
+	}
+
+	return newMaxFlow(fn, s, value), nil
+}
+
+// Dinic computes a maximum flow from s to t using the edge weights as capacities.
+// It repeatedly builds a level graph and saturates it with a blocking flow in
+// O(V^2 E), which is usually much faster than EdmondsKarp. If s or t are invalid
+// or a capacity is negative, a non-nil error is returned.
+func Dinic(g GraphView, s, t int) (*MaxFlow, error) {
+	if err := validateFlowEndpoints(g, s, t); err != nil {
+		return nil, err
+	}
+
+	fn, err := NewFlowNetworkFromGraph(g, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	value := 0
+	level := make([]int, g.V())
+	current := make([]int, g.V())
+
+	for fn.levelGraph(s, t, level) {
+		copy(current, fn.head)
+		value += fn.blockingFlow(s, t, level, current)
+	}
+
+	return newMaxFlow(fn, s, value), nil
+}
+
+// levelGraph computes the distance of every vertex from s in the residual network
+// and returns true if t is reachable.
+func (fn *FlowNetwork) levelGraph(s, t int, level []int) bool {
+	for i := range level {
+		level[i] = -1
+	}
+
+	level[s] = 0
+	queue := []int{s}
+
+	for head := 0; head < len(queue); head++ {
+		x := queue[head]
+
+		for a := fn.head[x]; a != -1; a = fn.next[a] {
+			if y := fn.to[a]; level[y] == -1 && fn.residual(a) > 0 {
+				level[y] = level[x] + 1
+				queue = append(queue, y)
+			}
+		}
+	}
+
+	return level[t] != -1
+}
+
+// blockingFlow saturates the level graph by repeatedly searching augmenting paths
+// with an explicit stack. current holds the next arc to examine for every vertex.
+func (fn *FlowNetwork) blockingFlow(s, t int, level, current []int) int {
+	total := 0
+	path := []int{}
+	v := s
+
+	for {
+		if v == t {
+			bottleneck := INFINITE_DISTANCE
+			for _, a := range path {
+				if r := fn.residual(a); r < bottleneck {
+					bottleneck = r
+				}
+			}
+
+			// retreat to the tail of the first saturated arc
+			k := -1
+			for i, a := range path {
+				fn.push(a, bottleneck)
+				if k == -1 && fn.residual(a) == 0 {
+					k = i
+				}
+			}
+
+			total += bottleneck
+			path = path[:k]
+			v = fn.tail(path, s)
+			continue
+		}
+
+		advanced := false
+
+		for ; current[v] != -1; current[v] = fn.next[current[v]] {
+			a := current[v]
+
+			if fn.residual(a) > 0 && level[fn.to[a]] == level[v]+1 {
+				path = append(path, a)
+				v = fn.to[a]
+				advanced = true
+				break
+			}
+		}
+
+		if advanced {
+			continue
+		}
+
+		if v == s {
+			return total
+		}
+
+		// v is a dead end, remove it from the level graph
+		level[v] = -1
+		path = path[:len(path)-1]
+		v = fn.tail(path, s)
+		current[v] = fn.next[current[v]]
+	}
+}
+
+// tail returns the vertex at the end of the given path starting at s.
+func (fn *FlowNetwork) tail(path []int, s int) int {
+	if len(path) == 0 {
+		return s
+	}
+	return fn.to[path[len(path)-1]]
+}
@@ -0,0 +1,211 @@
+package ds
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MinCostMaxFlow sends a maximum flow from s to t through the network at minimum
+// total cost and returns the value and cost of the flow. Any previous flow is
+// removed first, afterwards Edges reports the flow on every edge. If s or t are
+// invalid, the network has a cycle of negative cost reachable from s or the cost of
+// a path overflows an int, a non-nil error is returned.
+func (fn *FlowNetwork) MinCostMaxFlow(s, t int) (int, int, error) {
+	return fn.MinCostFlow(s, t, -1)
+}
+
+// MinCostFlow sends a flow of at most limit units from s to t through the network
+// at minimum total cost and returns the value and cost of the flow. A negative
+// limit means no limit. It uses successive shortest paths: Bellman-Ford computes
+// initial vertex potentials, which keep the reduced costs non-negative so that
+// every further augmenting path is found by Dijkstra in O(E log V). If s or t are
+// invalid, the network has a cycle of negative cost reachable from s or the cost of
+// a path overflows an int, a non-nil error is returned.
+func (fn *FlowNetwork) MinCostFlow(s, t, limit int) (int, int, error) {
+	if err := fn.validateEndpoints(s, t); err != nil {
+		return 0, 0, err
+	}
+
+	fn.Reset()
+
+	potential, err := fn.initialPotentials(s)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	flow, cost := 0, 0
+	dist := make([]int, fn.V())
+	parentArc := make([]int, fn.V())
+
+	for limit < 0 || flow < limit {
+		found, err := fn.shortestAugmentingPath(s, t, potential, dist, parentArc)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		if !found {
+			break
+		}
+
+		// vertices not reached remain unreachable, so their potentials do not matter
+		for v, d := range dist {
+			if d == INFINITE_DISTANCE {
+				continue
+			}
+
+			sum, ok := addInts(potential[v], d)
+			if !ok {
+				return 0, 0, fmt.Errorf("cost of a path to vertex %d overflows int", v)
+			}
+
+			potential[v] = sum
+		}
+
+		amount := INFINITE_DISTANCE
+		if limit >= 0 {
+			amount = limit - flow
+		}
+
+		for v := t; v != s; v = fn.from(parentArc[v]) {
+			if r := fn.residual(parentArc[v]); r < amount {
+				amount = r
+			}
+		}
+
+		for v := t; v != s; v = fn.from(parentArc[v]) {
+			fn.push(parentArc[v], amount)
+
+			step, ok := mulInts(amount, fn.cost[parentArc[v]])
+			if ok {
+				cost, ok = addInts(cost, step)
+			}
+
+			if !ok {
+				return 0, 0, errors.New("total cost of the flow overflows int")
+			}
+		}
+
+		flow += amount
+	}
+
+	return flow, cost, nil
+}
+
+// initialPotentials returns the costs of cheapest paths from s in the residual
+// network computed by Bellman-Ford, or 0 for vertices that cannot be reached.
+func (fn *FlowNetwork) initialPotentials(s int) ([]int, error) {
+	dist := make([]int, fn.V())
+	parentArc := make([]int, fn.V())
+
+	for v := range dist {
+		dist[v] = INFINITE_DISTANCE
+		parentArc[v] = -1
+	}
+
+	dist[s] = 0
+	last := -1
+
+	for round := 0; round < fn.V(); round++ {
+		last = -1
+
+		for a := range fn.to {
+			x, y := fn.from(a), fn.to[a]
+
+			if fn.residual(a) <= 0 || dist[x] == INFINITE_DISTANCE {
+				continue
+			}
+
+			d := addDistance(dist[x], fn.cost[a])
+
+			if d == INFINITE_DISTANCE {
+				return nil, fmt.Errorf("cost of a path to vertex %d overflows int", y)
+			}
+
+			if d < dist[y] {
+				dist[y] = d
+				parentArc[y] = a
+				last = y
+			}
+		}
+
+		if last == -1 {
+			break
+		}
+	}
+
+	if last != -1 {
+		// a relaxation in round V leads back to a negative cycle
+		for i := 0; i < fn.V(); i++ {
+			last = fn.from(parentArc[last])
+		}
+
+		cycle := []int{last}
+		for v := fn.from(parentArc[last]); v != last; v = fn.from(parentArc[v]) {
+			cycle = append(cycle, v)
+		}
+		cycle = append(cycle, last)
+		reverseInts(cycle)
+
+		return nil, fmt.Errorf("network has a cycle of negative cost: %v", cycle)
+	}
+
+	for v, d := range dist {
+		if d == INFINITE_DISTANCE {
+			dist[v] = 0
+		}
+	}
+
+	return dist, nil
+}
+
+// shortestAugmentingPath runs Dijkstra on the residual network with the reduced
+// costs cost(x, y) + potential[x] - potential[y]. It stores the reduced distances in
+// dist and the arcs of the shortest path tree in parentArc, and returns true if t is
+// reachable. If the cost of a path overflows an int, a non-nil error is returned.
+func (fn *FlowNetwork) shortestAugmentingPath(s, t int, potential, dist, parentArc []int) (bool, error) {
+	for v := range dist {
+		dist[v] = INFINITE_DISTANCE
+		parentArc[v] = -1
+	}
+
+	dist[s] = 0
+	pq := NewIndexedPriorityQueue[int, int](0, compareInts)
+	pq.Insert(s, 0)
+
+	for !pq.IsEmpty() {
+		x, _, _ := pq.ExtractMin()
+
+		for a := fn.head[x]; a != -1; a = fn.next[a] {
+			if fn.residual(a) <= 0 {
+				continue
+			}
+
+			y := fn.to[a]
+			reduced, ok := addInts(fn.cost[a], potential[x])
+			if ok {
+				reduced, ok = subInts(reduced, potential[y])
+			}
+
+			d := addDistance(dist[x], reduced)
+
+			if !ok || d == INFINITE_DISTANCE {
+				return false, fmt.Errorf("cost of a path to vertex %d overflows int", y)
+			}
+
+			if d >= dist[y] {
+				continue
+			}
+
+			dist[y] = d
+			parentArc[y] = a
+
+			if pq.Contains(y) {
+				pq.DecreaseKey(y, d)
+			} else {
+				pq.Insert(y, d)
+			}
+		}
+	}
+
+	return dist[t] != INFINITE_DISTANCE, nil
+}
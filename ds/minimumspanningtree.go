@@ -0,0 +1,161 @@
+package ds
+
+import (
+	"errors"
+	"sort"
+)
+
+// SpanningForest is a minimum spanning forest of an undirected graph, i.e. a
+// minimum spanning tree for each of its connected components.
+type SpanningForest struct {
+	edges      []Edge
+	weight     int
+	components int
+}
+
+// Edges returns the edges of the spanning forest.
+func (f *SpanningForest) Edges() []Edge {
+	return append([]Edge{}, f.edges...)
+}
+
+// Weight returns the total weight of the spanning forest.
+func (f *SpanningForest) Weight() int {
+	return f.weight
+}
+
+// Components returns the number of trees in the forest, which is the number of
+// connected components of the graph.
+func (f *SpanningForest) Components() int {
+	return f.components
+}
+
+// IsSpanningTree returns true if the graph is connected, i.e. the forest consists
+// of a single tree.
+func (f *SpanningForest) IsSpanningTree() bool {
+	return f.components <= 1
+}
+
+// add adds the given edge to the forest.
+func (f *SpanningForest) add(e Edge) {
+	f.edges = append(f.edges, e)
+	f.weight += e.Weight
+}
+
+// Kruskal computes a minimum spanning forest using Kruskal's algorithm in
+// O(E log E). If the graph is directed, a non-nil error is returned.
+func Kruskal(g GraphView) (*SpanningForest, error) {
+	if g.Directed() {
+		return nil, errors.New("minimum spanning trees are only defined for undirected graphs")
+	}
+
+	edges := viewEdges(g)
+	sort.SliceStable(edges, func(i, j int) bool { return edges[i].Weight < edges[j].Weight })
+
+	forest := &SpanningForest{}
+	uf := NewUnionFind(g.V())
+
+	for _, e := range edges {
+		if uf.Union(e.From, e.To) {
+			forest.add(e)
+		}
+	}
+
+	forest.components = uf.Count()
+
+	return forest, nil
+}
+
+// LazyPrim computes a minimum spanning forest using the lazy version of Prim's
+// algorithm in O(E log E), which keeps obsolete edges in the priority queue. If
+// the graph is directed, a non-nil error is returned.
+func LazyPrim(g GraphView) (*SpanningForest, error) {
+	if g.Directed() {
+		return nil, errors.New("minimum spanning trees are only defined for undirected graphs")
+	}
+
+	forest := &SpanningForest{}
+	marked := make([]bool, g.V())
+	pq := NewMinHeap(0, func(a, b Edge) int { return compareInts(a.Weight, b.Weight) })
+
+	visit := func(x int) {
+		marked[x] = true
+
+		for it := g.OutEdges(x); it.HasNext(); {
+			if e := nextEdge(it); !marked[e.To] {
+				pq.Insert(e)
+			}
+		}
+	}
+
+	for s := 0; s < g.V(); s++ {
+		if marked[s] {
+			continue
+		}
+
+		forest.components++
+		visit(s)
+
+		for !pq.IsEmpty() {
+			e, _ := pq.Extract()
+
+			if marked[e.To] {
+				continue
+			}
+
+			forest.add(e)
+			visit(e.To)
+		}
+	}
+
+	return forest, nil
+}
+
+// EagerPrim computes a minimum spanning forest using the eager version of Prim's
+// algorithm in O(E log V), which keeps at most one edge per vertex in an indexed
+// priority queue. If the graph is directed, a non-nil error is returned.
+func EagerPrim(g GraphView) (*SpanningForest, error) {
+	if g.Directed() {
+		return nil, errors.New("minimum spanning trees are only defined for undirected graphs")
+	}
+
+	forest := &SpanningForest{}
+	marked := make([]bool, g.V())
+	edgeTo := make([]Edge, g.V())
+	pq := NewIndexedPriorityQueue[int, int](0, compareInts)
+
+	for s := 0; s < g.V(); s++ {
+		if marked[s] {
+			continue
+		}
+
+		forest.components++
+		pq.Insert(s, 0)
+
+		for !pq.IsEmpty() {
+			x, _, _ := pq.ExtractMin()
+			marked[x] = true
+
+			if x != s {
+				forest.add(edgeTo[x])
+			}
+
+			for it := g.OutEdges(x); it.HasNext(); {
+				e := nextEdge(it)
+
+				if marked[e.To] {
+					continue
+				}
+
+				if weight, ok := pq.Priority(e.To); !ok {
+					edgeTo[e.To] = e
+					pq.Insert(e.To, e.Weight)
+				} else if e.Weight < weight {
+					edgeTo[e.To] = e
+					pq.DecreaseKey(e.To, e.Weight)
+				}
+			}
+		}
+	}
+
+	return forest, nil
+}
@@ -0,0 +1,269 @@
+package ds
+
+import (
+	"errors"
+	"math/bits"
+)
+
+// EvictionPolicy determines which end of a bounded MinMaxHeap gives way when an
+// element is inserted into a full heap.
+type EvictionPolicy int
+
+const (
+	// EvictMin removes the smallest element, i.e. the heap keeps the largest elements.
+	EvictMin EvictionPolicy = iota
+	// EvictMax removes the largest element, i.e. the heap keeps the smallest elements.
+	EvictMax
+)
+
+// MinMaxHeap implements an array based min-max heap, a double ended priority queue
+// giving access to both its smallest and its largest element. Elements on even
+// levels are smaller than all their descendants, elements on odd levels are greater.
+type MinMaxHeap[T any] struct {
+	data        []T
+	compareFunc CompareFunc[T]
+	n           int
+	limit       int
+	policy      EvictionPolicy
+}
+
+// NewMinMaxHeap creates a new unbounded min-max heap with the given capacity and
+// comparison function.
+func NewMinMaxHeap[T any](capacity int, compareFunc CompareFunc[T]) *MinMaxHeap[T] {
+	return &MinMaxHeap[T]{data: make([]T, capacity), compareFunc: compareFunc}
+}
+
+// NewBoundedMinMaxHeap creates a new min-max heap holding at most limit elements.
+// Inserting into a full heap evicts an element from the end given by policy. If
+// limit is less than 1, the function panics.
+func NewBoundedMinMaxHeap[T any](limit int, policy EvictionPolicy, compareFunc CompareFunc[T]) *MinMaxHeap[T] {
+	if limit < 1 {
+		panic("limit must be greater than 0")
+	}
+
+	return &MinMaxHeap[T]{data: make([]T, limit), compareFunc: compareFunc, limit: limit, policy: policy}
+}
+
+// Insert adds the given element to the heap. If the heap is bounded and full, the
+// element at the end given by the eviction policy is removed and returned together
+// with a true flag. This may be the given element itself if it would have been
+// evicted right away. Otherwise a zero value and a false flag are returned.
+func (h *MinMaxHeap[T]) Insert(t T) (T, bool) {
+	if h.limit > 0 && h.n == h.limit {
+		return h.replace(t), true
+	}
+
+	if h.n == len(h.data) {
+		h.data = ResizeSlice(h.data, 2*len(h.data)+1)
+	}
+
+	h.data[h.n] = t
+	h.n++
+	h.pushUp(h.n - 1)
+
+	var zero T
+	return zero, false
+}
+
+// PeekMin returns the smallest element without removing it. If the heap is empty,
+// a non-nil error is returned.
+func (h *MinMaxHeap[T]) PeekMin() (T, error) {
+	if h.IsEmpty() {
+		var t T
+		return t, errors.New("cannot peek into empty heap")
+	}
+
+	return h.data[0], nil
+}
+
+// PeekMax returns the largest element without removing it. If the heap is empty,
+// a non-nil error is returned.
+func (h *MinMaxHeap[T]) PeekMax() (T, error) {
+	if h.IsEmpty() {
+		var t T
+		return t, errors.New("cannot peek into empty heap")
+	}
+
+	return h.data[h.maxIndex()], nil
+}
+
+// ExtractMin removes and returns the smallest element. If the heap is empty,
+// a non-nil error is returned.
+func (h *MinMaxHeap[T]) ExtractMin() (T, error) {
+	if h.IsEmpty() {
+		var t T
+		return t, errors.New("cannot extract from empty heap")
+	}
+
+	return h.removeAt(0), nil
+}
+
+// ExtractMax removes and returns the largest element. If the heap is empty,
+// a non-nil error is returned.
+func (h *MinMaxHeap[T]) ExtractMax() (T, error) {
+	if h.IsEmpty() {
+		var t T
+		return t, errors.New("cannot extract from empty heap")
+	}
+
+	return h.removeAt(h.maxIndex()), nil
+}
+
+// Size returns the number of elements in the heap.
+func (h *MinMaxHeap[T]) Size() int {
+	return h.n
+}
+
+// IsEmpty returns true if the heap is empty, false otherwise.
+func (h *MinMaxHeap[T]) IsEmpty() bool {
+	return h.n == 0
+}
+
+// Limit returns the maximum number of elements of a bounded heap, or 0 if the heap
+// is unbounded.
+func (h *MinMaxHeap[T]) Limit() int {
+	return h.limit
+}
+
+// replace inserts t into a full bounded heap and returns the evicted element.
+func (h *MinMaxHeap[T]) replace(t T) T {
+	if h.policy == EvictMin {
+		if h.compareFunc(t, h.data[0]) <= 0 {
+			return t
+		}
+
+		evicted := h.data[0]
+		h.data[0] = t
+		h.pushDown(0)
+		return evicted
+	}
+
+	i := h.maxIndex()
+
+	if h.compareFunc(t, h.data[i]) >= 0 {
+		return t
+	}
+
+	evicted := h.data[i]
+	h.data[i] = t
+
+	// the new element may be smaller than the root it sits below
+	if i > 0 && h.compareFunc(h.data[i], h.data[0]) < 0 {
+		h.data[i], h.data[0] = h.data[0], h.data[i]
+	}
+
+	h.pushDown(i)
+	return evicted
+}
+
+// removeAt removes the element at index i, which must be the index of the minimum
+// or the maximum, and returns it.
+func (h *MinMaxHeap[T]) removeAt(i int) T {
+	t := h.data[i]
+	h.n--
+	h.data[i] = h.data[h.n]
+
+	var zero T
+	h.data[h.n] = zero
+
+	if i < h.n {
+		h.pushDown(i)
+	}
+
+	return t
+}
+
+// maxIndex returns the index of the largest element of a non-empty heap.
+func (h *MinMaxHeap[T]) maxIndex() int {
+	switch {
+	case h.n == 1:
+		return 0
+	case h.n == 2 || h.compareFunc(h.data[1], h.data[2]) >= 0:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// isMinLevel returns true if index i lies on an even (min) level of the heap.
+func isMinLevel(i int) bool {
+	return (bits.Len(uint(i+1))-1)%2 == 0
+}
+
+// before returns true if the element at index i has to be placed above the element
+// at index j on a min level (minLevel == true) or on a max level (minLevel == false).
+func (h *MinMaxHeap[T]) before(i, j int, minLevel bool) bool {
+	c := h.compareFunc(h.data[i], h.data[j])
+
+	if minLevel {
+		return c < 0
+	}
+
+	return c > 0
+}
+
+// pushUp moves the element at index i up to its correct position.
+func (h *MinMaxHeap[T]) pushUp(i int) {
+	if i == 0 {
+		return
+	}
+
+	minLevel := isMinLevel(i)
+	parent := (i - 1) / 2
+
+	if h.before(parent, i, minLevel) {
+		h.data[i], h.data[parent] = h.data[parent], h.data[i]
+		h.pushUpLevel(parent, !minLevel)
+	} else {
+		h.pushUpLevel(i, minLevel)
+	}
+}
+
+// pushUpLevel moves the element at index i up along the levels of the same kind.
+func (h *MinMaxHeap[T]) pushUpLevel(i int, minLevel bool) {
+	for i >= 3 {
+		grandparent := ((i-1)/2 - 1) / 2
+
+		if !h.before(i, grandparent, minLevel) {
+			return
+		}
+
+		h.data[i], h.data[grandparent] = h.data[grandparent], h.data[i]
+		i = grandparent
+	}
+}
+
+// pushDown moves the element at index i down to its correct position.
+func (h *MinMaxHeap[T]) pushDown(i int) {
+	minLevel := isMinLevel(i)
+
+	for 2*i+1 < h.n {
+		// find the extreme element among children and grandchildren
+		m := 2*i + 1
+		candidates := [...]int{2*i + 2, 4*i + 3, 4*i + 4, 4*i + 5, 4*i + 6}
+
+		for _, c := range candidates {
+			if c < h.n && h.before(c, m, minLevel) {
+				m = c
+			}
+		}
+
+		if !h.before(m, i, minLevel) {
+			return
+		}
+
+		h.data[i], h.data[m] = h.data[m], h.data[i]
+
+		if m <= 2*i+2 {
+			return
+		}
+
+		parent := (m - 1) / 2
+
+		if h.before(parent, m, minLevel) {
+			h.data[m], h.data[parent] = h.data[parent], h.data[m]
+		}
+
+		i = m
+	}
+}
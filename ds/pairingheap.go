@@ -0,0 +1,204 @@
+package ds
+
+import "errors"
+
+// PairingHeapNode is a handle to an element stored in a PairingHeap. It can be
+// used to decrease the element's key after insertion.
+type PairingHeapNode[T any] struct {
+	value   T
+	owner   *heapOwner
+	child   *PairingHeapNode[T]
+	sibling *PairingHeapNode[T]
+	// prev points to the parent for the leftmost child, otherwise to the left sibling.
+	prev *PairingHeapNode[T]
+}
+
+// Value returns the element stored in the node.
+func (node *PairingHeapNode[T]) Value() T {
+	return node.value
+}
+
+// PairingHeap implements a pairing min-heap. Insert, Meld and DecreaseKey run in
+// O(1) (amortized for DecreaseKey), Extract in amortized O(log n).
+type PairingHeap[T any] struct {
+	root        *PairingHeapNode[T]
+	compareFunc CompareFunc[T]
+	n           int
+	owner       *heapOwner
+}
+
+// NewPairingHeap creates a new pairing heap. The element with the smallest value
+// according to compareFunc is at the top.
+func NewPairingHeap[T any](compareFunc CompareFunc[T]) *PairingHeap[T] {
+	return &PairingHeap[T]{compareFunc: compareFunc, owner: &heapOwner{}}
+}
+
+// Insert adds the given element to the heap.
+func (h *PairingHeap[T]) Insert(t T) {
+	h.InsertNode(t)
+}
+
+// InsertNode adds the given element to the heap and returns a handle to it.
+func (h *PairingHeap[T]) InsertNode(t T) *PairingHeapNode[T] {
+	node := &PairingHeapNode[T]{value: t, owner: h.owner}
+	h.root = h.link(h.root, node)
+	h.n++
+	return node
+}
+
+// Peek returns the smallest element without removing it. If the heap is empty,
+// a non-nil error is returned.
+func (h *PairingHeap[T]) Peek() (T, error) {
+	if h.IsEmpty() {
+		var t T
+		return t, errors.New("cannot peek into empty heap")
+	}
+
+	return h.root.value, nil
+}
+
+// Extract removes and returns the smallest element. If the heap is empty,
+// a non-nil error is returned.
+func (h *PairingHeap[T]) Extract() (T, error) {
+	if h.IsEmpty() {
+		var t T
+		return t, errors.New("cannot extract from empty heap")
+	}
+
+	root := h.root
+	h.root = h.mergePairs(root.child)
+	h.n--
+
+	root.child = nil
+	root.owner = nil
+
+	return root.value, nil
+}
+
+// Meld moves all elements of other into this heap in O(1). Handles obtained from
+// other stay valid and now refer to this heap; other is left empty.
+func (h *PairingHeap[T]) Meld(other *PairingHeap[T]) {
+	if other == nil || other == h {
+		return
+	}
+
+	h.root = h.link(h.root, other.root)
+	h.n += other.n
+
+	other.owner.parent = h.owner
+	other.owner = &heapOwner{}
+	other.root = nil
+	other.n = 0
+}
+
+// DecreaseKey replaces the element referred to by the given handle with a smaller
+// one. If the new element is greater than the current one, or the handle refers to
+// an element that was extracted or belongs to another heap, a non-nil error is
+// returned.
+func (h *PairingHeap[T]) DecreaseKey(node *PairingHeapNode[T], t T) error {
+	if node == nil {
+		return errors.New("cannot decrease key of nil node")
+	}
+
+	if node.owner == nil || node.owner.find() != h.owner {
+		return errors.New("node is not an element of the heap")
+	}
+
+	if h.compareFunc(t, node.value) > 0 {
+		return errors.New("new key is greater than the current key")
+	}
+
+	node.value = t
+
+	if node == h.root {
+		return nil
+	}
+
+	h.cut(node)
+	h.root = h.link(h.root, node)
+
+	return nil
+}
+
+// Size returns the number of elements in the heap.
+func (h *PairingHeap[T]) Size() int {
+	return h.n
+}
+
+// IsEmpty returns true if the heap is empty, false otherwise.
+func (h *PairingHeap[T]) IsEmpty() bool {
+	return h.n == 0
+}
+
+// link merges two heap ordered trees by making the root with the greater value the
+// leftmost child of the other one. The root of the resulting tree is returned.
+func (h *PairingHeap[T]) link(a, b *PairingHeapNode[T]) *PairingHeapNode[T] {
+	if a == nil {
+		return b
+	}
+
+	if b == nil {
+		return a
+	}
+
+	if h.compareFunc(b.value, a.value) < 0 {
+		a, b = b, a
+	}
+
+	b.prev = a
+	b.sibling = a.child
+
+	if a.child != nil {
+		a.child.prev = b
+	}
+
+	a.child = b
+	a.prev = nil
+	a.sibling = nil
+
+	return a
+}
+
+// cut detaches the subtree rooted at the given node from its parent.
+func (h *PairingHeap[T]) cut(node *PairingHeapNode[T]) {
+	if node.prev.child == node {
+		node.prev.child = node.sibling
+	} else {
+		node.prev.sibling = node.sibling
+	}
+
+	if node.sibling != nil {
+		node.sibling.prev = node.prev
+	}
+
+	node.prev = nil
+	node.sibling = nil
+}
+
+// mergePairs combines the list of siblings starting at first into a single tree
+// using the standard two-pass strategy.
+func (h *PairingHeap[T]) mergePairs(first *PairingHeapNode[T]) *PairingHeapNode[T] {
+	pairs := []*PairingHeapNode[T]{}
+
+	for first != nil {
+		a := first
+		b := a.sibling
+		first = nil
+
+		if b != nil {
+			first = b.sibling
+			b.prev, b.sibling = nil, nil
+		}
+
+		a.prev, a.sibling = nil, nil
+		pairs = append(pairs, h.link(a, b))
+	}
+
+	var root *PairingHeapNode[T]
+
+	for i := len(pairs) - 1; i >= 0; i-- {
+		root = h.link(pairs[i], root)
+	}
+
+	return root
+}
@@ -0,0 +1,220 @@
+package ds
+
+import "fmt"
+
+// PathSearch is the result of a point-to-point shortest path query, as computed by
+// AStar and BidirectionalDijkstra.
+type PathSearch struct {
+	path     []int
+	cost     int
+	expanded int
+}
+
+// Found returns true if there is a path from the source to the target.
+func (ps *PathSearch) Found() bool {
+	return ps.path != nil
+}
+
+// Path returns the vertices on a shortest path from the source to the target. If
+// there is no such path, nil is returned.
+func (ps *PathSearch) Path() []int {
+	if ps.path == nil {
+		return nil
+	}
+	return append([]int{}, ps.path...)
+}
+
+// Cost returns the length of the shortest path, or INFINITE_DISTANCE if there is
+// no path.
+func (ps *PathSearch) Cost() int {
+	return ps.cost
+}
+
+// Expanded returns the number of times the edges of a vertex were scanned, which
+// measures the work done by the search.
+func (ps *PathSearch) Expanded() int {
+	return ps.expanded
+}
+
+// AStar computes a shortest path from s to t using the A* algorithm, which expands
+// vertices in order of their distance from s plus the estimate h(v) of their
+// distance to t. If h never overestimates the distance to t, the path is optimal.
+// If h is also consistent, i.e. h(x) <= w + h(y) for every edge x -> y with weight
+// w, every vertex is expanded at most once; otherwise vertices are reopened when a
+// shorter path to them is found. A nil heuristic makes it Dijkstra's algorithm
+// stopping at t. Paths whose length reaches INFINITE_DISTANCE are ignored. If s or t
+// are not vertices of the graph or the search reaches an edge with a negative
+// weight, a non-nil error is returned.
+func AStar(g GraphView, s, t int, h func(v int) int) (*PathSearch, error) {
+	if err := validatePathEndpoints(g, s, t); err != nil {
+		return nil, err
+	}
+
+	if h == nil {
+		h = func(v int) int { return 0 }
+	}
+
+	ps := &PathSearch{cost: INFINITE_DISTANCE}
+	sp := newShortestPaths(g.V(), s)
+	pq := NewIndexedPriorityQueue[int, int](0, compareInts)
+	pq.Insert(s, h(s))
+
+	for !pq.IsEmpty() {
+		x, _, _ := pq.ExtractMin()
+
+		if x == t {
+			ps.path, ps.cost = sp.PathTo(t), sp.dist[t]
+			break
+		}
+
+		ps.expanded++
+
+		for it := g.OutEdges(x); it.HasNext(); {
+			e := nextEdge(it)
+
+			if e.Weight < 0 {
+				return nil, fmt.Errorf("edge %d -> %d has negative weight %d", x, e.To, e.Weight)
+			}
+
+			if !sp.relax(x, e.To, e.Weight) {
+				continue
+			}
+
+			if pq.Contains(e.To) {
+				pq.DecreaseKey(e.To, addDistance(sp.dist[e.To], h(e.To)))
+			} else {
+				pq.Insert(e.To, addDistance(sp.dist[e.To], h(e.To)))
+			}
+		}
+	}
+
+	return ps, nil
+}
+
+// BidirectionalDijkstra computes a shortest path from s to t by running Dijkstra's
+// algorithm forward from s and backward from t at the same time, always advancing
+// the search with the closer frontier. It stops once no path through an unscanned
+// vertex can beat the best path found where the searches meet, which usually
+// expands far fewer vertices than a single search. The backward search of a directed
+// graph runs on its reverse, which is built first in O(V + E). Paths whose length
+// reaches INFINITE_DISTANCE are ignored. If s or t are not vertices of the graph or
+// the search reaches an edge with a negative weight, a non-nil error is returned.
+func BidirectionalDijkstra(g GraphView, s, t int) (*PathSearch, error) {
+	if err := validatePathEndpoints(g, s, t); err != nil {
+		return nil, err
+	}
+
+	forward := newDijkstraSide(g, s, false)
+	var backward *dijkstraSide
+
+	if g.Directed() {
+		backward = newDijkstraSide(reverseGraph(g), t, true)
+	} else {
+		backward = newDijkstraSide(g, t, false)
+	}
+
+	ps := &PathSearch{cost: INFINITE_DISTANCE}
+	meet := -1
+
+	if s == t {
+		ps.cost, meet = 0, s
+	}
+
+	for !forward.pq.IsEmpty() && !backward.pq.IsEmpty() {
+		_, f, _ := forward.pq.PeekMin()
+		_, b, _ := backward.pq.PeekMin()
+
+		// every path through an unscanned vertex is at least f + b long
+		if addDistance(f, b) >= ps.cost {
+			break
+		}
+
+		side, other := forward, backward
+		if b < f {
+			side, other = backward, forward
+		}
+
+		x, _, _ := side.pq.ExtractMin()
+		ps.expanded++
+
+		for it := side.g.OutEdges(x); it.HasNext(); {
+			e := nextEdge(it)
+
+			if err := side.relax(e); err != nil {
+				return nil, err
+			}
+
+			if d := addDistance(side.sp.dist[e.To], other.sp.dist[e.To]); d < ps.cost {
+				ps.cost, meet = d, e.To
+			}
+		}
+	}
+
+	if meet == -1 {
+		return ps, nil
+	}
+
+	ps.path = forward.sp.PathTo(meet)
+
+	for x := backward.sp.parent[meet]; x != -1; x = backward.sp.parent[x] {
+		ps.path = append(ps.path, x)
+	}
+
+	return ps, nil
+}
+
+// dijkstraSide is one of the two searches of BidirectionalDijkstra. The edges of a
+// reversed side point against the edges of the original graph.
+type dijkstraSide struct {
+	g        GraphView
+	sp       *ShortestPaths
+	pq       *IndexedPriorityQueue[int, int]
+	reversed bool
+}
+
+// newDijkstraSide returns a search on g starting at s.
+func newDijkstraSide(g GraphView, s int, reversed bool) *dijkstraSide {
+	side := &dijkstraSide{
+		g:        g,
+		sp:       newShortestPaths(g.V(), s),
+		pq:       NewIndexedPriorityQueue[int, int](0, compareInts),
+		reversed: reversed,
+	}
+
+	side.pq.Insert(s, 0)
+
+	return side
+}
+
+// relax relaxes the given edge and updates the priority queue. If the edge has a
+// negative weight, a non-nil error naming the edge of the original graph is
+// returned.
+func (side *dijkstraSide) relax(e Edge) error {
+	if e.Weight < 0 {
+		if side.reversed {
+			e.From, e.To = e.To, e.From
+		}
+		return fmt.Errorf("edge %d -> %d has negative weight %d", e.From, e.To, e.Weight)
+	}
+
+	if !side.sp.relax(e.From, e.To, e.Weight) {
+		return nil
+	}
+
+	if side.pq.Contains(e.To) {
+		side.pq.DecreaseKey(e.To, side.sp.dist[e.To])
+	} else {
+		side.pq.Insert(e.To, side.sp.dist[e.To])
+	}
+
+	return nil
+}
+
+// validatePathEndpoints returns a non-nil error if s or t are not vertices of the
+// graph.
+func validatePathEndpoints(g GraphView, s, t int) error {
+	if err := validateViewVertex(g, s); err != nil {
+		return err
+	}
+	return validateViewVertex(g, t)
+}
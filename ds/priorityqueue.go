@@ -0,0 +1,12 @@
+package ds
+
+// PriorityQueue is the interface shared by the heaps of this package. Elements are
+// inserted in any order and removed in the order given by the heap's comparison
+// function, starting with the element at the top.
+type PriorityQueue[T any] interface {
+	Insert(t T)
+	Peek() (T, error)
+	Extract() (T, error)
+	Size() int
+	IsEmpty() bool
+}
@@ -0,0 +1,220 @@
+package ds
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// INFINITE_DISTANCE is the distance reported for unreachable vertices.
+	INFINITE_DISTANCE int = math.MaxInt
+)
+
+// ShortestPaths is a shortest path tree rooted at a single source vertex, as computed
+// by Dijkstra and BellmanFord.
+type ShortestPaths struct {
+	source        int
+	dist          []int
+	parent        []int
+	negativeCycle []int
+}
+
+// newShortestPaths returns a shortest path tree with only the source reached.
+func newShortestPaths(v, s int) *ShortestPaths {
+	sp := &ShortestPaths{source: s, dist: make([]int, v), parent: make([]int, v)}
+
+	for i := 0; i < v; i++ {
+		sp.dist[i] = INFINITE_DISTANCE
+		sp.parent[i] = -1
+	}
+
+	sp.dist[s] = 0
+
+	return sp
+}
+
+// Source returns the source vertex of the shortest path tree.
+func (sp *ShortestPaths) Source() int {
+	return sp.source
+}
+
+// DistTo returns the length of the shortest path from the source to the given
+// vertex, or INFINITE_DISTANCE if there is no such path.
+func (sp *ShortestPaths) DistTo(v int) int {
+	if v < 0 || v >= len(sp.dist) {
+		return INFINITE_DISTANCE
+	}
+	return sp.dist[v]
+}
+
+// HasPathTo returns true if there is a path from the source to the given vertex.
+func (sp *ShortestPaths) HasPathTo(v int) bool {
+	return sp.DistTo(v) != INFINITE_DISTANCE
+}
+
+// PathTo returns the vertices on a shortest path from the source to the given
+// vertex. If there is no such path, or the graph has a negative cycle, nil is returned.
+func (sp *ShortestPaths) PathTo(v int) []int {
+	if !sp.HasPathTo(v) || sp.HasNegativeCycle() {
+		return nil
+	}
+
+	path := []int{}
+
+	for x := v; x != -1; x = sp.parent[x] {
+		path = append(path, x)
+	}
+
+	reverseInts(path)
+
+	return path
+}
+
+// HasNegativeCycle returns true if a negative cycle is reachable from the source.
+// In that case the distances are meaningless.
+func (sp *ShortestPaths) HasNegativeCycle() bool {
+	return sp.negativeCycle != nil
+}
+
+// NegativeCycle returns the vertices of a negative cycle reachable from the source
+// in the order of its edges, with the first vertex repeated at the end. If there
+// is no such cycle, nil is returned.
+func (sp *ShortestPaths) NegativeCycle() []int {
+	if sp.negativeCycle == nil {
+		return nil
+	}
+	return append([]int{}, sp.negativeCycle...)
+}
+
+// Dijkstra computes the shortest paths from vertex s using Dijkstra's algorithm
+// in O(E log V). Paths whose length reaches INFINITE_DISTANCE are ignored. If s is
+// not a vertex of the graph or the graph has an edge with a negative weight, a
+// non-nil error is returned.
+func Dijkstra(g GraphView, s int) (*ShortestPaths, error) {
+	if err := validateViewVertex(g, s); err != nil {
+		return nil, err
+	}
+
+	for x := 0; x < g.V(); x++ {
+		for it := g.OutEdges(x); it.HasNext(); {
+			if e := nextEdge(it); e.Weight < 0 {
+				return nil, fmt.Errorf("edge %d -> %d has negative weight %d", x, e.To, e.Weight)
+			}
+		}
+	}
+
+	sp := newShortestPaths(g.V(), s)
+	pq := NewIndexedPriorityQueue[int, int](0, compareInts)
+	pq.Insert(s, 0)
+
+	for !pq.IsEmpty() {
+		x, _, _ := pq.ExtractMin()
+
+		for it := g.OutEdges(x); it.HasNext(); {
+			e := nextEdge(it)
+
+			if !sp.relax(x, e.To, e.Weight) {
+				continue
+			}
+
+			if pq.Contains(e.To) {
+				pq.DecreaseKey(e.To, sp.dist[e.To])
+			} else {
+				pq.Insert(e.To, sp.dist[e.To])
+			}
+		}
+	}
+
+	return sp, nil
+}
+
+// BellmanFord computes the shortest paths from vertex s using the Bellman-Ford
+// algorithm in O(VE). Negative edge weights are allowed; if a negative cycle is
+// reachable from s, it is reported by HasNegativeCycle and NegativeCycle. Paths whose
+// length reaches INFINITE_DISTANCE are ignored. If s is not a vertex of the graph, a
+// non-nil error is returned.
+func BellmanFord(g GraphView, s int) (*ShortestPaths, error) {
+	if err := validateViewVertex(g, s); err != nil {
+		return nil, err
+	}
+
+	v := g.V()
+	sp := newShortestPaths(v, s)
+
+	for round := 0; round < v; round++ {
+		relaxed := -1
+
+		for x := 0; x < v; x++ {
+			if sp.dist[x] == INFINITE_DISTANCE {
+				continue
+			}
+
+			for it := g.OutEdges(x); it.HasNext(); {
+				if e := nextEdge(it); sp.relax(x, e.To, e.Weight) {
+					relaxed = e.To
+				}
+			}
+		}
+
+		if relaxed == -1 {
+			return sp, nil
+		}
+
+		// a relaxation in round V proves a negative cycle
+		if round == v-1 {
+			sp.negativeCycle = sp.findCycle(relaxed, v)
+		}
+	}
+
+	return sp, nil
+}
+
+// relax relaxes the edge x -> y with the given weight and returns true if the
+// distance to y was improved.
+func (sp *ShortestPaths) relax(x, y, weight int) bool {
+	d := addDistance(sp.dist[x], weight)
+
+	if d >= sp.dist[y] {
+		return false
+	}
+
+	sp.dist[y] = d
+	sp.parent[y] = x
+
+	return true
+}
+
+// addDistance returns d + weight, or INFINITE_DISTANCE if d is INFINITE_DISTANCE or
+// the sum reaches it.
+func addDistance(d, weight int) int {
+	if d == INFINITE_DISTANCE || (weight > 0 && d >= INFINITE_DISTANCE-weight) {
+		return INFINITE_DISTANCE
+	}
+	return d + weight
+}
+
+// findCycle follows the parent pointers from v, which must lead into a cycle, and
+// returns the cycle in edge order with the first vertex repeated at the end.
+func (sp *ShortestPaths) findCycle(v, n int) []int {
+	for i := 0; i < n; i++ {
+		v = sp.parent[v]
+	}
+
+	cycle := []int{v}
+
+	for x := sp.parent[v]; x != v; x = sp.parent[x] {
+		cycle = append(cycle, x)
+	}
+
+	cycle = append(cycle, v)
+	reverseInts(cycle)
+
+	return cycle
+}
+
+// reverseInts reverses the given slice in place.
+func reverseInts(a []int) {
+	for i, j := 0, len(a)-1; i < j; i, j = i+1, j-1 {
+		a[i], a[j] = a[j], a[i]
+	}
+}
@@ -0,0 +1,123 @@
+package ds
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// WriteSNAP writes the graph as a SNAP edge list: comment lines starting with "#"
+// describe the graph, followed by one line "x y" per edge, or "x y w" if any edge
+// has a weight other than 1. Each edge of an undirected graph is written once. It
+// returns the first error of the writer.
+func (g *Graph) WriteSNAP(w io.Writer) error {
+	edges := g.Edges()
+	weighted := false
+
+	for _, e := range edges {
+		if e.Weight != 1 {
+			weighted = true
+			break
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+
+	if g.directed {
+		fmt.Fprintln(bw, "# Directed graph")
+	} else {
+		fmt.Fprintln(bw, "# Undirected graph")
+	}
+
+	fmt.Fprintf(bw, "# Nodes: %d Edges: %d\n", g.v, len(edges))
+
+	if weighted {
+		fmt.Fprintln(bw, "# FromNodeId\tToNodeId\tWeight")
+	} else {
+		fmt.Fprintln(bw, "# FromNodeId\tToNodeId")
+	}
+
+	for _, e := range edges {
+		if weighted {
+			fmt.Fprintf(bw, "%d\t%d\t%d\n", e.From, e.To, e.Weight)
+		} else {
+			fmt.Fprintf(bw, "%d\t%d\n", e.From, e.To)
+		}
+	}
+
+	return bw.Flush()
+}
+
+// ReadSNAP reads a graph from a SNAP edge list with one edge "x y" or "x y w" per
+// line, where edges without a weight have weight 1. Lines starting with "#" are
+// comments. Vertices are the non-negative integers up to the largest one in the
+// input, or up to the node count of a comment "# Nodes: V ..." if that is larger.
+// The input is parsed line by line and the graph grows as vertices appear. If it is
+// malformed or has more than MAX_READ_VERTICES vertices, a non-nil error naming the
+// offending line is returned.
+func ReadSNAP(r io.Reader, directed bool) (*Graph, error) {
+	lr := newLineReader(r)
+	g := NewGraph(0, directed)
+
+	for {
+		line, ok, err := lr.next()
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			break
+		}
+
+		if line[0] == '#' {
+			var v, e int
+
+			if n, _ := fmt.Sscanf(strings.TrimSpace(line[1:]), "Nodes: %d Edges: %d", &v, &e); n >= 1 && v > 0 {
+				if err := validateVertexCount(v); err != nil {
+					return nil, fmt.Errorf("line %d: %v", lr.number, err)
+				}
+
+				g.grow(v)
+			}
+			continue
+		}
+
+		edge, err := parseSNAPEdge(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", lr.number, err)
+		}
+
+		g.grow(edge[0] + 1)
+		g.grow(edge[1] + 1)
+		g.AddEdge(edge[0], edge[1], edge[2])
+	}
+
+	return g, nil
+}
+
+// parseSNAPEdge parses an edge "x y" or "x y w".
+func parseSNAPEdge(line string) ([3]int, error) {
+	edge := [3]int{0, 0, 1}
+	fields := strings.Fields(line)
+
+	if len(fields) != 2 && len(fields) != 3 {
+		return edge, fmt.Errorf("invalid edge %q, expected \"x y\" or \"x y w\"", line)
+	}
+
+	for i, f := range fields {
+		v, err := strconv.Atoi(f)
+		if err != nil || (i < 2 && v < 0) {
+			return edge, fmt.Errorf("invalid edge %q, expected \"x y\" or \"x y w\"", line)
+		}
+
+		if i < 2 && v >= MAX_READ_VERTICES {
+			return edge, fmt.Errorf("vertex %d exceeds the limit of %d vertices", v, MAX_READ_VERTICES)
+		}
+
+		edge[i] = v
+	}
+
+	return edge, nil
+}
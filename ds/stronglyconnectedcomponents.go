@@ -0,0 +1,238 @@
+package ds
+
+import (
+	"errors"
+	"sort"
+)
+
+// StronglyConnectedComponents holds the strongly connected components of a directed
+// graph, as computed by TarjanSCC and KosarajuSCC. Components are numbered in
+// topological order of the condensation, i.e. every edge between two components
+// leads from a lower to a higher component id.
+type StronglyConnectedComponents struct {
+	g     GraphView
+	id    []int
+	count int
+}
+
+// Count returns the number of strongly connected components.
+func (scc *StronglyConnectedComponents) Count() int {
+	return scc.count
+}
+
+// ID returns the component id of the given vertex, or -1 if it is not a vertex of
+// the graph.
+func (scc *StronglyConnectedComponents) ID(v int) int {
+	if v < 0 || v >= len(scc.id) {
+		return -1
+	}
+	return scc.id[v]
+}
+
+// IDs returns a copy of the component ids of all vertices.
+func (scc *StronglyConnectedComponents) IDs() []int {
+	return append([]int{}, scc.id...)
+}
+
+// StronglyConnected returns true if x and y are in the same component.
+func (scc *StronglyConnectedComponents) StronglyConnected(x, y int) bool {
+	return scc.ID(x) != -1 && scc.ID(x) == scc.ID(y)
+}
+
+// Components returns the vertices of every component in increasing order, indexed
+// by component id.
+func (scc *StronglyConnectedComponents) Components() [][]int {
+	components := make([][]int, scc.count)
+
+	for v, id := range scc.id {
+		components[id] = append(components[id], v)
+	}
+
+	return components
+}
+
+// Condensation returns the condensation of the graph, a directed acyclic graph with
+// one vertex per component. All edges between two components are combined into a
+// single edge whose weight is obtained by folding the edge weights with aggregate.
+// If aggregate is nil, the weights are summed up.
+func (scc *StronglyConnectedComponents) Condensation(aggregate func(a, b int) int) *Graph {
+	if aggregate == nil {
+		aggregate = func(a, b int) int { return a + b }
+	}
+
+	weights := map[[2]int]int{}
+	pairs := [][2]int{}
+
+	for x := 0; x < scc.g.V(); x++ {
+		for it := scc.g.OutEdges(x); it.HasNext(); {
+			e := nextEdge(it)
+			pair := [2]int{scc.id[x], scc.id[e.To]}
+
+			if pair[0] == pair[1] {
+				continue
+			}
+
+			if w, ok := weights[pair]; ok {
+				weights[pair] = aggregate(w, e.Weight)
+			} else {
+				weights[pair] = e.Weight
+				pairs = append(pairs, pair)
+			}
+		}
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i][0] != pairs[j][0] {
+			return pairs[i][0] < pairs[j][0]
+		}
+		return pairs[i][1] < pairs[j][1]
+	})
+
+	condensation := NewGraph(scc.count, true)
+
+	for i := len(pairs) - 1; i >= 0; i-- {
+		condensation.AddEdge(pairs[i][0], pairs[i][1], weights[pairs[i]])
+	}
+
+	return condensation
+}
+
+// newStronglyConnectedComponents returns an empty result for the given graph.
+func newStronglyConnectedComponents(g GraphView) *StronglyConnectedComponents {
+	scc := &StronglyConnectedComponents{g: g, id: make([]int, g.V())}
+
+	for v := range scc.id {
+		scc.id[v] = -1
+	}
+
+	return scc
+}
+
+// reverseIDs renumbers components found in reverse topological order.
+func (scc *StronglyConnectedComponents) reverseIDs() {
+	for v := range scc.id {
+		scc.id[v] = scc.count - 1 - scc.id[v]
+	}
+}
+
+// TarjanSCC computes the strongly connected components using Tarjan's algorithm in
+// O(V + E). The depth first search is iterative. If the graph is undirected, a
+// non-nil error is returned.
+func TarjanSCC(g GraphView) (*StronglyConnectedComponents, error) {
+	if !g.Directed() {
+		return nil, errors.New("strongly connected components are only defined for directed graphs")
+	}
+
+	scc := newStronglyConnectedComponents(g)
+	index := make([]int, g.V())
+	low := make([]int, g.V())
+	onStack := make([]bool, g.V())
+	stack := []int{}
+	counter := 0
+
+	for i := range index {
+		index[i] = -1
+	}
+
+	discover := func(v int) {
+		index[v], low[v] = counter, counter
+		counter++
+		stack = append(stack, v)
+		onStack[v] = true
+	}
+
+	for s := 0; s < g.V(); s++ {
+		if index[s] != -1 {
+			continue
+		}
+
+		discover(s)
+		frames := []dfsFrame{{v: s, edges: g.OutEdges(s)}}
+
+		for len(frames) > 0 {
+			top := &frames[len(frames)-1]
+			x := top.v
+
+			if top.edges.HasNext() {
+				y := nextEdge(top.edges).To
+
+				if index[y] == -1 {
+					discover(y)
+					frames = append(frames, dfsFrame{v: y, edges: g.OutEdges(y)})
+				} else if onStack[y] && index[y] < low[x] {
+					low[x] = index[y]
+				}
+
+				continue
+			}
+
+			frames = frames[:len(frames)-1]
+
+			if len(frames) > 0 {
+				parent := frames[len(frames)-1].v
+				if low[x] < low[parent] {
+					low[parent] = low[x]
+				}
+			}
+
+			if low[x] != index[x] {
+				continue
+			}
+
+			// x is the root of a component consisting of x and all vertices above it
+			for {
+				v := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[v] = false
+				scc.id[v] = scc.count
+
+				if v == x {
+					break
+				}
+			}
+
+			scc.count++
+		}
+	}
+
+	scc.reverseIDs()
+
+	return scc, nil
+}
+
+// KosarajuSCC computes the strongly connected components using Kosaraju's algorithm
+// in O(V + E): a depth first search on the reverse graph yields an order in which
+// a second depth first search on the graph discovers one component at a time. If
+// the graph is undirected, a non-nil error is returned.
+func KosarajuSCC(g GraphView) (*StronglyConnectedComponents, error) {
+	if !g.Directed() {
+		return nil, errors.New("strongly connected components are only defined for directed graphs")
+	}
+
+	postorder := make([]int, 0, g.V())
+	DepthFirstSearchAll(reverseGraph(g), &GraphVisitor{
+		FinishVertex: func(v int) bool { postorder = append(postorder, v); return true },
+	})
+
+	scc := newStronglyConnectedComponents(g)
+	tree := newSearchTree(g.V())
+	state := make([]byte, g.V())
+	visitor := &GraphVisitor{
+		DiscoverVertex: func(v int) bool { scc.id[v] = scc.count; return true },
+	}
+
+	for i := len(postorder) - 1; i >= 0; i-- {
+		s := postorder[i]
+
+		if state[s] != undiscovered {
+			continue
+		}
+
+		dfs(g, tree, state, s, visitor)
+		scc.count++
+	}
+
+	scc.reverseIDs()
+
+	return scc, nil
+}
@@ -0,0 +1,142 @@
+package ds
+
+import (
+	"errors"
+	"fmt"
+)
+
+// TopologicalSort returns the vertices of a directed acyclic graph in topological
+// order using Kahn's algorithm, i.e. every edge points from a vertex to a later one.
+// If the graph is undirected or has a cycle, a non-nil error is returned.
+func TopologicalSort(g GraphView) ([]int, error) {
+	queue := []int{}
+
+	return kahn(g, func(v int) { queue = append(queue, v) }, func() int {
+		v := queue[0]
+		queue = queue[1:]
+		return v
+	})
+}
+
+// LexicographicTopologicalSort returns the lexicographically smallest topological
+// order of a directed acyclic graph, which makes the output independent of the
+// order in which edges were added. It runs in O(E + V log V). If the graph is
+// undirected or has a cycle, a non-nil error is returned.
+func LexicographicTopologicalSort(g GraphView) ([]int, error) {
+	pq := NewMinHeap(0, compareInts)
+
+	return kahn(g, pq.Insert, func() int {
+		v, _ := pq.Extract()
+		return v
+	})
+}
+
+// TopologicalSortDFS returns the vertices of a directed acyclic graph in topological
+// order, computed as the reverse postorder of a depth first search. If the graph is
+// undirected or has a cycle, a non-nil error is returned.
+func TopologicalSortDFS(g GraphView) ([]int, error) {
+	if !g.Directed() {
+		return nil, errors.New("topological order is only defined for directed graphs")
+	}
+
+	order := make([]int, 0, g.V())
+	visitor := &GraphVisitor{
+		BackEdge:     func(x, y int) bool { return false },
+		FinishVertex: func(v int) bool { order = append(order, v); return true },
+	}
+
+	if DepthFirstSearchAll(g, visitor).Stopped() {
+		return nil, cycleError(g)
+	}
+
+	reverseInts(order)
+
+	return order, nil
+}
+
+// FindCycle returns the vertices of a directed cycle in the order of its edges, with
+// the first vertex repeated at the end. If the graph is acyclic, nil is returned.
+// If the graph is undirected, a non-nil error is returned.
+func FindCycle(g GraphView) ([]int, error) {
+	if !g.Directed() {
+		return nil, errors.New("cycle detection is only supported for directed graphs")
+	}
+
+	from, to := -1, -1
+	visitor := &GraphVisitor{
+		BackEdge: func(x, y int) bool {
+			from, to = x, y
+			return false
+		},
+	}
+
+	tree := DepthFirstSearchAll(g, visitor)
+
+	if !tree.Stopped() {
+		return nil, nil
+	}
+
+	// the back edge from -> to closes the tree path to -> ... -> from
+	cycle := []int{to}
+	for v := from; v != to; v = tree.Parent(v) {
+		cycle = append(cycle, v)
+	}
+	cycle = append(cycle, to)
+
+	reverseInts(cycle)
+
+	return cycle, nil
+}
+
+// kahn runs Kahn's algorithm, keeping the vertices with no remaining incoming edges
+// in a container given by its push and pop functions.
+func kahn(g GraphView, push func(v int), pop func() int) ([]int, error) {
+	if !g.Directed() {
+		return nil, errors.New("topological order is only defined for directed graphs")
+	}
+
+	indegree := make([]int, g.V())
+
+	for x := 0; x < g.V(); x++ {
+		for it := g.OutEdges(x); it.HasNext(); {
+			indegree[nextEdge(it).To]++
+		}
+	}
+
+	pending := 0
+	for v := 0; v < g.V(); v++ {
+		if indegree[v] == 0 {
+			push(v)
+			pending++
+		}
+	}
+
+	order := make([]int, 0, g.V())
+
+	for ; pending > 0; pending-- {
+		x := pop()
+		order = append(order, x)
+
+		for it := g.OutEdges(x); it.HasNext(); {
+			y := nextEdge(it).To
+			indegree[y]--
+
+			if indegree[y] == 0 {
+				push(y)
+				pending++
+			}
+		}
+	}
+
+	if len(order) != g.V() {
+		return nil, cycleError(g)
+	}
+
+	return order, nil
+}
+
+// cycleError returns an error describing a cycle of the given graph.
+func cycleError(g GraphView) error {
+	cycle, _ := FindCycle(g)
+	return fmt.Errorf("graph has a cycle: %v", cycle)
+}
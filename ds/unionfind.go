@@ -0,0 +1,62 @@
+package ds
+
+// UnionFind implements a disjoint-set data structure over the elements 0 to n-1
+// using union by size and path compression. All operations run in nearly constant
+// amortized time.
+type UnionFind struct {
+	parent []int
+	size   []int
+	count  int
+}
+
+// NewUnionFind creates a new union-find structure with n singleton sets.
+func NewUnionFind(n int) *UnionFind {
+	uf := &UnionFind{parent: make([]int, n), size: make([]int, n), count: n}
+
+	for i := 0; i < n; i++ {
+		uf.parent[i] = i
+		uf.size[i] = 1
+	}
+
+	return uf
+}
+
+// Find returns the representative of the set containing p.
+func (uf *UnionFind) Find(p int) int {
+	for p != uf.parent[p] {
+		uf.parent[p] = uf.parent[uf.parent[p]]
+		p = uf.parent[p]
+	}
+
+	return p
+}
+
+// Union merges the sets containing p and q. It returns true if the sets were
+// different, false if p and q were already connected.
+func (uf *UnionFind) Union(p, q int) bool {
+	rootP, rootQ := uf.Find(p), uf.Find(q)
+
+	if rootP == rootQ {
+		return false
+	}
+
+	if uf.size[rootP] < uf.size[rootQ] {
+		rootP, rootQ = rootQ, rootP
+	}
+
+	uf.parent[rootQ] = rootP
+	uf.size[rootP] += uf.size[rootQ]
+	uf.count--
+
+	return true
+}
+
+// Connected returns true if p and q are in the same set.
+func (uf *UnionFind) Connected(p, q int) bool {
+	return uf.Find(p) == uf.Find(q)
+}
+
+// Count returns the number of disjoint sets.
+func (uf *UnionFind) Count() int {
+	return uf.count
+}
@@ -1,5 +1,6 @@
 package ds
 
+import "math"
 
 // ResizeSlice resizes the given slice to the given capacity. If the new capacity is less than the 
 // length of the slice or less than 0, the function panics.
@@ -13,3 +14,55 @@ func ResizeSlice[T any](slice []T, newCapacity int) []T {
 	copy(newSlice, slice)
 	return newSlice
 }
+
+// compareInts compares two integers in ascending order.
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// addInts returns a + b and false if the sum overflows an int.
+func addInts(a, b int) (int, bool) {
+	sum := a + b
+	return sum, (sum > a) == (b > 0)
+}
+
+// subInts returns a - b and false if the difference overflows an int.
+func subInts(a, b int) (int, bool) {
+	diff := a - b
+	return diff, (diff < a) == (b > 0)
+}
+
+// mulInts returns a * b and false if the product overflows an int.
+func mulInts(a, b int) (int, bool) {
+	product := a * b
+	if a != 0 && (product/a != b || (a == -1 && b == math.MinInt)) {
+		return product, false
+	}
+	return product, true
+}
+
+// Number is a constraint for numeric types that can be used as edge weights.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// compareNumbers compares two numbers in ascending order.
+func compareNumbers[N Number](a, b N) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
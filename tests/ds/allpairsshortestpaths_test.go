@@ -0,0 +1,120 @@
+package ds_test
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/welschma/godsa/ds"
+)
+
+var allPairsAlgorithms = map[string]func(ds.GraphView) *ds.AllPairsShortestPaths{
+	"FloydWarshall": ds.FloydWarshall,
+	"Johnson":       ds.Johnson,
+}
+
+func TestAllPairsShortestPaths(t *testing.T) {
+	g := newTestGraph(4, true, [][3]int{{0, 2, -2}, {2, 3, 2}, {3, 1, -1}, {1, 0, 4}, {1, 2, 3}})
+
+	want := [][]int{
+		{0, -1, -2, 0},
+		{4, 0, 2, 4},
+		{5, 1, 0, 2},
+		{3, -1, 1, 0},
+	}
+
+	for name, algorithm := range allPairsAlgorithms {
+		t.Run(name, func(t *testing.T) {
+			apsp := algorithm(g)
+
+			if apsp.HasNegativeCycle() {
+				t.Fatal("unexpected negative cycle")
+			}
+
+			for x := range want {
+				for y := range want[x] {
+					if got := apsp.Dist(x, y); got != want[x][y] {
+						t.Errorf("dist %d -> %d: want %d, got %d", x, y, want[x][y], got)
+					}
+				}
+			}
+
+			if got := apsp.Path(1, 3); !reflect.DeepEqual(got, []int{1, 0, 2, 3}) {
+				t.Errorf("path 1 -> 3: want [1 0 2 3], got %v", got)
+			}
+
+			if got := apsp.Path(2, 2); !reflect.DeepEqual(got, []int{2}) {
+				t.Errorf("path 2 -> 2: want [2], got %v", got)
+			}
+		})
+	}
+}
+
+func TestAllPairsShortestPathsUnreachable(t *testing.T) {
+	g := newTestGraph(3, true, [][3]int{{0, 1, 5}})
+
+	for name, algorithm := range allPairsAlgorithms {
+		apsp := algorithm(g)
+
+		if apsp.HasPath(1, 0) || apsp.Dist(1, 0) != ds.INFINITE_DISTANCE || apsp.Path(1, 0) != nil {
+			t.Errorf("%s: vertex 0 should not be reachable from 1", name)
+		}
+
+		if apsp.HasPath(0, 5) {
+			t.Errorf("%s: vertex out of bounds should not be reachable", name)
+		}
+	}
+}
+
+func TestAllPairsShortestPathsNegativeCycle(t *testing.T) {
+	g := newTestGraph(5, true, [][3]int{{0, 1, 1}, {1, 2, -1}, {2, 3, -1}, {3, 1, 1}, {3, 4, 2}})
+	weights := edgeWeights(g)
+
+	for name, algorithm := range allPairsAlgorithms {
+		apsp := algorithm(g)
+
+		if !apsp.HasNegativeCycle() {
+			t.Fatalf("%s: expected a negative cycle", name)
+		}
+
+		cycle := apsp.NegativeCycle()
+		if cycle[0] != cycle[len(cycle)-1] || pathWeight(weights, cycle) >= 0 {
+			t.Errorf("%s: expected a closed negative cycle, got %v", name, cycle)
+		}
+
+		if apsp.Path(0, 4) != nil {
+			t.Errorf("%s: paths are undefined in the presence of a negative cycle", name)
+		}
+	}
+}
+
+func TestAllPairsShortestPathsRandomized(t *testing.T) {
+	r := rand.New(rand.NewSource(11))
+
+	for i := 0; i < 10; i++ {
+		// non-negative weights so that the results can be checked against Dijkstra
+		g := newRandomGraph(r, 25, 80, i%2 == 0, 0, 30)
+		weights := edgeWeights(g)
+		floydWarshall := ds.FloydWarshall(g)
+		johnson := ds.Johnson(g)
+
+		for s := 0; s < g.V(); s++ {
+			sp, _ := ds.Dijkstra(g, s)
+
+			for v := 0; v < g.V(); v++ {
+				if floydWarshall.Dist(s, v) != sp.DistTo(v) || johnson.Dist(s, v) != sp.DistTo(v) {
+					t.Fatalf("dist %d -> %d: dijkstra %d, floyd-warshall %d, johnson %d",
+						s, v, sp.DistTo(v), floydWarshall.Dist(s, v), johnson.Dist(s, v))
+				}
+
+				if sp.HasPathTo(v) && pathWeight(weights, johnson.Path(s, v)) != sp.DistTo(v) {
+					t.Fatalf("johnson path %d -> %d does not have weight %d", s, v, sp.DistTo(v))
+				}
+
+				if sp.HasPathTo(v) && pathWeight(weights, floydWarshall.Path(s, v)) != sp.DistTo(v) {
+					t.Fatalf("floyd-warshall path %d -> %d does not have weight %d", s, v, sp.DistTo(v))
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,264 @@
+package ds_test
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/welschma/godsa/ds"
+)
+
+// bruteForceAssignment returns the best total cost of assigning every row of a matrix
+// with at most as many rows as columns to a distinct column.
+func bruteForceAssignment(costs [][]int, maximize bool) int {
+	best, found := 0, false
+	used := make([]bool, len(costs[0]))
+
+	var assign func(row, total int)
+	assign = func(row, total int) {
+		if row == len(costs) {
+			if !found || (maximize && total > best) || (!maximize && total < best) {
+				best, found = total, true
+			}
+			return
+		}
+
+		for j := range used {
+			if !used[j] {
+				used[j] = true
+				assign(row+1, total+costs[row][j])
+				used[j] = false
+			}
+		}
+	}
+
+	assign(0, 0)
+
+	return best
+}
+
+// transpose returns the transposed matrix.
+func transpose(costs [][]int) [][]int {
+	transposed := make([][]int, len(costs[0]))
+
+	for j := range transposed {
+		transposed[j] = make([]int, len(costs))
+		for i := range costs {
+			transposed[j][i] = costs[i][j]
+		}
+	}
+
+	return transposed
+}
+
+// checkAssignment fails the test if the assignment does not cover the smaller side
+// with distinct rows and columns, or its cost does not add up.
+func checkAssignment(t *testing.T, costs [][]int, a *ds.Assignment) {
+	t.Helper()
+
+	n, m := len(costs), len(costs[0])
+	total := 0
+
+	for _, p := range a.Pairs() {
+		if a.Col(p[0]) != p[1] || a.Row(p[1]) != p[0] {
+			t.Fatalf("inconsistent pair %v", p)
+		}
+		total += costs[p[0]][p[1]]
+	}
+
+	expected := n
+	if m < n {
+		expected = m
+	}
+
+	if len(a.Pairs()) != expected {
+		t.Fatalf("expected %d pairs, got %v", expected, a.Pairs())
+	}
+
+	if total != a.Cost() {
+		t.Fatalf("pairs add up to %d, expected %d", total, a.Cost())
+	}
+}
+
+func TestHungarian(t *testing.T) {
+	costs := [][]int{
+		{9, 2, 7, 8},
+		{6, 4, 3, 7},
+		{5, 8, 1, 8},
+		{7, 6, 9, 4},
+	}
+
+	a, err := ds.Hungarian(costs, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if a.Cost() != 13 || !reflect.DeepEqual(a.Pairs(), [][2]int{{0, 1}, {1, 0}, {2, 2}, {3, 3}}) {
+		t.Fatalf("expected cost 13, got %d with %v", a.Cost(), a.Pairs())
+	}
+
+	a, _ = ds.Hungarian(costs, true)
+	if a.Cost() != bruteForceAssignment(costs, true) {
+		t.Fatalf("expected cost %d, got %d", bruteForceAssignment(costs, true), a.Cost())
+	}
+
+	if a.Col(-1) != -1 || a.Col(4) != -1 || a.Row(4) != -1 {
+		t.Fatal("expected -1 for invalid rows and columns")
+	}
+}
+
+func TestHungarianRectangular(t *testing.T) {
+	costs := [][]int{
+		{4, 1, 3},
+		{2, 0, 5},
+	}
+
+	a, err := ds.Hungarian(costs, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if a.Cost() != 3 || a.Row(2) != -1 {
+		t.Fatalf("expected cost 3 leaving column 2 unassigned, got %d with %v", a.Cost(), a.Pairs())
+	}
+
+	a, _ = ds.Hungarian(transpose(costs), false)
+	if a.Cost() != 3 || a.Col(2) != -1 {
+		t.Fatalf("expected cost 3 leaving row 2 unassigned, got %d with %v", a.Cost(), a.Pairs())
+	}
+
+	a, _ = ds.Hungarian(nil, false)
+	if a.Cost() != 0 || len(a.Pairs()) != 0 {
+		t.Fatal("expected an empty assignment")
+	}
+
+	if _, err := ds.Hungarian([][]int{{1, 2}, {3}}, false); err == nil {
+		t.Fatal("expected an error for a ragged matrix")
+	}
+}
+
+func TestHungarianOverflow(t *testing.T) {
+	for _, tc := range []struct {
+		costs    [][]int
+		maximize bool
+	}{
+		{[][]int{{math.MaxInt, 0}, {0, math.MaxInt}}, true},
+		{[][]int{{math.MaxInt - 1, math.MaxInt}, {math.MaxInt, math.MaxInt - 1}}, false},
+		{[][]int{{math.MinInt, 0}}, true},
+		{[][]int{{math.MinInt, math.MaxInt}, {math.MaxInt, math.MinInt}}, false},
+	} {
+		if _, err := ds.Hungarian(tc.costs, tc.maximize); err == nil {
+			t.Fatalf("costs %v: expected an error for an overflowing sum", tc.costs)
+		}
+	}
+
+	a, err := ds.Hungarian([][]int{{math.MaxInt - 1, 1}, {1, math.MaxInt - 1}}, false)
+	if err != nil || a.Cost() != 2 {
+		t.Fatalf("expected cost 2, got %v", err)
+	}
+
+	g := newTestGraph(4, false, [][3]int{{0, 2, math.MaxInt}, {1, 3, math.MaxInt}})
+
+	if _, _, err := ds.HungarianGraph(g, []int{0, 1}, false); err == nil {
+		t.Fatal("expected an error for an overflowing total weight")
+	}
+}
+
+func TestHungarianMatchesBruteForce(t *testing.T) {
+	r := rand.New(rand.NewSource(15))
+
+	for i := 0; i < 300; i++ {
+		n, m := 1+r.Intn(6), 1+r.Intn(6)
+		costs := make([][]int, n)
+
+		for row := range costs {
+			costs[row] = make([]int, m)
+			for col := range costs[row] {
+				costs[row][col] = r.Intn(41) - 20
+			}
+		}
+
+		for _, maximize := range []bool{false, true} {
+			a, err := ds.Hungarian(costs, maximize)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			checkAssignment(t, costs, a)
+
+			expected := 0
+			if n <= m {
+				expected = bruteForceAssignment(costs, maximize)
+			} else {
+				expected = bruteForceAssignment(transpose(costs), maximize)
+			}
+
+			if a.Cost() != expected {
+				t.Fatalf("matrix %d: expected cost %d, got %d", i, expected, a.Cost())
+			}
+		}
+	}
+}
+
+func TestHungarianGraph(t *testing.T) {
+	// workers 0-2 and tasks 3-5, worker 2 can only do task 5
+	g := newTestGraph(6, false, [][3]int{
+		{0, 3, 4}, {0, 4, 1}, {0, 5, 3}, {1, 3, 2}, {1, 4, 0}, {1, 5, 5}, {2, 5, 2}, {1, 4, 7},
+	})
+
+	m, cost, err := ds.HungarianGraph(g, []int{0, 1, 2}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cost != 5 || !reflect.DeepEqual(m.Pairs(), [][2]int{{0, 4}, {1, 3}, {2, 5}}) {
+		t.Fatalf("expected cost 5, got %d with %v", cost, m.Pairs())
+	}
+
+	m, cost, _ = ds.HungarianGraph(g, []int{0, 1, 2}, true)
+	if cost != 13 || !reflect.DeepEqual(m.Pairs(), [][2]int{{0, 3}, {1, 4}, {2, 5}}) {
+		t.Fatalf("expected cost 13, got %d with %v", cost, m.Pairs())
+	}
+
+	// tasks 2 and 3 can only be done by worker 0, workers 1 and 4 are idle
+	if _, _, err := ds.HungarianGraph(newTestGraph(5, false, [][3]int{{0, 2, 1}, {0, 3, 1}}), []int{0, 1, 4}, false); err == nil {
+		t.Fatal("expected an error if the smaller side cannot be matched")
+	}
+
+	if _, _, err := ds.HungarianGraph(newTestGraph(3, false, [][3]int{{0, 1, 1}, {1, 2, 1}, {2, 0, 1}}), []int{0}, false); err == nil {
+		t.Fatal("expected an error for a graph that is not bipartite")
+	}
+
+	for _, left := range [][]int{{0, 1}, {0, 0, 2}, {0, 6}} {
+		if _, _, err := ds.HungarianGraph(g, left, false); err == nil {
+			t.Fatalf("expected an error for the side %v", left)
+		}
+	}
+}
+
+func TestHungarianGraphDisconnected(t *testing.T) {
+	// workers 1 and 2 in two components, tasks 0, 3, 4 and the isolated task 5
+	g := newTestGraph(6, false, [][3]int{{0, 1, 3}, {2, 3, 1}, {2, 4, 2}})
+
+	m, cost, err := ds.HungarianGraph(g, []int{1, 2}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cost != 4 || !reflect.DeepEqual(m.Pairs(), [][2]int{{0, 1}, {2, 3}}) {
+		t.Fatalf("expected cost 4, got %d with %v", cost, m.Pairs())
+	}
+
+	// both workers can only do task 0
+	if _, _, err := ds.HungarianGraph(newTestGraph(6, false, [][3]int{{0, 1, 1}, {0, 2, 1}}), []int{1, 2}, false); err == nil {
+		t.Fatal("expected an error if the smaller side cannot be matched")
+	}
+
+	g.AddEdge(5, 1, 1)
+
+	m, cost, _ = ds.HungarianGraph(g, []int{1, 2}, true)
+	if cost != 5 || !reflect.DeepEqual(m.Pairs(), [][2]int{{0, 1}, {2, 4}}) {
+		t.Fatalf("expected cost 5, got %d with %v", cost, m.Pairs())
+	}
+}
@@ -0,0 +1,138 @@
+package ds_test
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/welschma/godsa/ds"
+)
+
+func TestHopcroftTarjan(t *testing.T) {
+	// two triangles {0, 1, 2} and {2, 3, 4} sharing vertex 2, a bridge 4 - 5 and
+	// a path 5 - 6 - 7; 8 is isolated
+	g := newTestGraph(9, false, [][3]int{
+		{0, 1, 1}, {1, 2, 1}, {2, 0, 1}, {2, 3, 1}, {3, 4, 1}, {4, 2, 1}, {4, 5, 2}, {5, 6, 3}, {6, 7, 4},
+	})
+
+	bc, err := ds.HopcroftTarjan(g)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, want := bc.ArticulationPoints(), []int{2, 4, 5, 6}; !reflect.DeepEqual(got, want) {
+		t.Errorf("articulation points: want %v, got %v", want, got)
+	}
+
+	wantBridges := map[ds.Edge]bool{{From: 4, To: 5, Weight: 2}: true, {From: 5, To: 6, Weight: 3}: true, {From: 6, To: 7, Weight: 4}: true}
+	bridges := bc.Bridges()
+
+	if len(bridges) != len(wantBridges) {
+		t.Fatalf("expected %d bridges, got %v", len(wantBridges), bridges)
+	}
+
+	for _, e := range bridges {
+		if !wantBridges[e] {
+			t.Errorf("unexpected bridge %v", e)
+		}
+	}
+
+	if bc.Count() != 5 {
+		t.Errorf("expected 5 biconnected components, got %d", bc.Count())
+	}
+
+	if bc.ComponentOf(0, 1) != bc.ComponentOf(2, 0) || bc.ComponentOf(0, 1) == bc.ComponentOf(3, 4) {
+		t.Error("edges of the triangles are assigned to the wrong components")
+	}
+
+	if bc.ComponentOf(0, 4) != -1 || !bc.IsBridge(5, 4) || bc.IsBridge(1, 2) {
+		t.Error("component membership reported for wrong edges")
+	}
+}
+
+func TestHopcroftTarjanParallelEdges(t *testing.T) {
+	g := newTestGraph(3, false, [][3]int{{0, 1, 1}, {0, 1, 1}, {1, 2, 1}, {2, 2, 1}})
+	bc, _ := ds.HopcroftTarjan(g)
+
+	if bc.IsBridge(0, 1) || !bc.IsBridge(1, 2) {
+		t.Error("parallel edges must not be bridges")
+	}
+
+	if got := bc.ArticulationPoints(); !reflect.DeepEqual(got, []int{1}) {
+		t.Errorf("articulation points: want [1], got %v", got)
+	}
+}
+
+func TestHopcroftTarjanDirected(t *testing.T) {
+	if _, err := ds.HopcroftTarjan(ds.NewGraph(2, true)); err == nil {
+		t.Error("expected error for directed graph")
+	}
+}
+
+func TestHopcroftTarjanDeepGraph(t *testing.T) {
+	n := 1000000
+	g := ds.NewGraph(n, false)
+	for i := 0; i < n-1; i++ {
+		g.AddEdge(i, i+1, 1)
+	}
+
+	bc, _ := ds.HopcroftTarjan(g)
+
+	if len(bc.Bridges()) != n-1 || len(bc.ArticulationPoints()) != n-2 {
+		t.Errorf("expected %d bridges and %d articulation points, got %d and %d",
+			n-1, n-2, len(bc.Bridges()), len(bc.ArticulationPoints()))
+	}
+}
+
+// componentCount returns the number of connected components of g without vertex
+// skip and without the edge skipEdge.
+func componentCount(g *ds.Graph, skip int, skipEdge ds.Edge) int {
+	uf := ds.NewUnionFind(g.V())
+	skippedEdge := false
+
+	for _, e := range g.Edges() {
+		if e.From == skip || e.To == skip {
+			continue
+		}
+
+		if e == skipEdge && !skippedEdge {
+			skippedEdge = true
+			continue
+		}
+
+		uf.Union(e.From, e.To)
+	}
+
+	if skip >= 0 {
+		return uf.Count() - 1
+	}
+
+	return uf.Count()
+}
+
+func TestHopcroftTarjanRandomized(t *testing.T) {
+	r := rand.New(rand.NewSource(15))
+	none := ds.Edge{From: -1, To: -1}
+
+	for i := 0; i < 20; i++ {
+		g := newRandomGraph(r, 30, 35, false, 1, 1)
+		bc, _ := ds.HopcroftTarjan(g)
+		components := componentCount(g, -1, none)
+
+		for v := 0; v < g.V(); v++ {
+			if want := componentCount(g, v, none) > components; bc.IsArticulationPoint(v) != want {
+				t.Fatalf("vertex %d: expected articulation point %v", v, want)
+			}
+		}
+
+		for _, e := range g.Edges() {
+			if e.From == e.To {
+				continue
+			}
+
+			if want := componentCount(g, -1, e) > components; bc.IsBridge(e.From, e.To) != want {
+				t.Fatalf("edge %v: expected bridge %v", e, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package ds_test
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/welschma/godsa/ds"
+)
+
+// checkBipartition fails the test if the coloring of a bipartite graph has an edge
+// between vertices of the same color, or if the odd cycle of a non-bipartite graph
+// is not a closed walk of odd length along edges of the graph.
+func checkBipartition(t *testing.T, g *ds.Graph, b *ds.Bipartition) {
+	t.Helper()
+
+	if b.IsBipartite() {
+		for _, e := range g.Edges() {
+			if b.Color(e.From) == b.Color(e.To) {
+				t.Fatalf("edge %d - %d connects vertices of the same color", e.From, e.To)
+			}
+		}
+		return
+	}
+
+	weights := edgeWeights(g)
+	cycle := b.OddCycle()
+
+	if len(cycle)%2 != 0 || cycle[0] != cycle[len(cycle)-1] {
+		t.Fatalf("%v is not a closed walk of odd length", cycle)
+	}
+
+	if pathWeight(weights, cycle) == -1 {
+		t.Fatalf("%v is not a cycle of the graph", cycle)
+	}
+}
+
+func TestBipartite(t *testing.T) {
+	// even cycle 0 - 1 - 2 - 3 - 0 and the path 4 - 5
+	g := newTestGraph(6, false, [][3]int{{0, 1, 1}, {1, 2, 1}, {2, 3, 1}, {3, 0, 1}, {4, 5, 1}})
+
+	b, err := ds.Bipartite(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !b.IsBipartite() || b.OddCycle() != nil {
+		t.Fatal("expected the graph to be bipartite")
+	}
+
+	if colors := b.Colors(); !reflect.DeepEqual(colors, []int{0, 1, 0, 1, 0, 1}) {
+		t.Fatalf("unexpected colors %v", colors)
+	}
+
+	left, right := b.Sides()
+	if !reflect.DeepEqual(left, []int{0, 2, 4}) || !reflect.DeepEqual(right, []int{1, 3, 5}) {
+		t.Fatalf("unexpected sides %v and %v", left, right)
+	}
+
+	if b.Color(-1) != -1 || b.Color(6) != -1 {
+		t.Fatal("expected -1 for invalid vertices")
+	}
+}
+
+func TestBipartiteOddCycle(t *testing.T) {
+	// 0 - 1 - 2 - 3 - 4 - 0 is a cycle of length 5 hanging off the path 5 - 6 - 0
+	g := newTestGraph(7, false, [][3]int{{5, 6, 1}, {6, 0, 1}, {0, 1, 1}, {1, 2, 1}, {2, 3, 1}, {3, 4, 1}, {4, 0, 1}})
+
+	b, err := ds.Bipartite(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b.IsBipartite() || b.Colors() != nil || b.Color(0) != -1 {
+		t.Fatal("expected the graph not to be bipartite")
+	}
+
+	if len(b.OddCycle()) != 6 {
+		t.Fatalf("expected a cycle of length 5, got %v", b.OddCycle())
+	}
+
+	checkBipartition(t, g, b)
+
+	b, _ = ds.Bipartite(newTestGraph(2, false, [][3]int{{0, 1, 1}, {1, 1, 1}}))
+	if !reflect.DeepEqual(b.OddCycle(), []int{1, 1}) {
+		t.Fatalf("expected the self-loop as odd cycle, got %v", b.OddCycle())
+	}
+
+	if _, err := ds.Bipartite(ds.NewGraph(2, true)); err == nil {
+		t.Fatal("expected an error for a directed graph")
+	}
+}
+
+func TestBipartiteRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(14))
+
+	for i := 0; i < 300; i++ {
+		v := 1 + r.Intn(12)
+		g := newRandomGraph(r, v, r.Intn(2*v), false, 1, 1)
+
+		b, err := ds.Bipartite(g)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		checkBipartition(t, g, b)
+	}
+}
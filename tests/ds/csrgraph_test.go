@@ -0,0 +1,161 @@
+package ds_test
+
+import (
+	"bytes"
+	"math/rand"
+	"reflect"
+	"runtime"
+	"testing"
+
+	"github.com/welschma/godsa/ds"
+)
+
+func TestCSRGraph(t *testing.T) {
+	r := rand.New(rand.NewSource(21))
+
+	for i := 0; i < 100; i++ {
+		g := newRandomGraph(r, 1+r.Intn(20), r.Intn(60), r.Intn(2) == 0, 0, 10)
+		c := ds.NewCSRGraph(g)
+
+		if c.V() != g.V() || c.E() != g.E() || c.Directed() != g.Directed() {
+			t.Fatalf("graph %d: expected %d vertices and %d edges, got %d and %d", i, g.V(), g.E(), c.V(), c.E())
+		}
+
+		var expected, got bytes.Buffer
+		g.Write(&expected)
+		c.ToGraph().Write(&got)
+
+		if got.String() != expected.String() {
+			t.Fatalf("graph %d: expected\n%s\ngot\n%s", i, expected.String(), got.String())
+		}
+
+		for v := 0; v < g.V(); v++ {
+			degree, _ := g.Degree(v)
+			if d, err := c.Degree(v); err != nil || d != degree {
+				t.Fatalf("graph %d: expected degree %d of vertex %d, got %d", i, degree, v, d)
+			}
+		}
+
+		// the algorithms see the same adjacency order on both representations
+		bfsGraph, _ := ds.BreadthFirstSearch(g, 0, nil)
+		bfsCSR, _ := ds.BreadthFirstSearch(c, 0, nil)
+		dfsGraph := ds.DepthFirstSearchAll(g, nil)
+		dfsCSR := ds.DepthFirstSearchAll(c, nil)
+		spGraph, _ := ds.Dijkstra(g, 0)
+		spCSR, _ := ds.Dijkstra(c, 0)
+		bfGraph, _ := ds.BellmanFord(g, 0)
+		bfCSR, _ := ds.BellmanFord(c, 0)
+
+		if !reflect.DeepEqual(bfsGraph, bfsCSR) || !reflect.DeepEqual(dfsGraph, dfsCSR) ||
+			!reflect.DeepEqual(spGraph, spCSR) || !reflect.DeepEqual(bfGraph, bfCSR) {
+			t.Fatalf("graph %d: results differ between Graph and CSRGraph", i)
+		}
+	}
+
+	if _, err := ds.NewCSRGraph(ds.NewGraph(2, true)).Degree(2); err == nil {
+		t.Fatal("expected an error for an invalid vertex")
+	}
+}
+
+func TestNewCSRGraphFromEdges(t *testing.T) {
+	edges := ds.NewArrayBag[ds.Edge]()
+	for _, e := range []ds.Edge{{From: 2, To: 0, Weight: 1}, {From: 0, To: 1, Weight: 2}, {From: 2, To: 1, Weight: 3}, {From: 1, To: 1, Weight: 4}} {
+		edges.Add(e)
+	}
+
+	c, err := ds.NewCSRGraphFromEdges(4, false, edges.CreateIterator())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the stream order is kept for every vertex, and undirected edges appear twice
+	expected := [][3]int{{0, 2, 1}, {0, 1, 2}, {1, 0, 2}, {1, 2, 3}, {1, 1, 4}, {1, 1, 4}, {2, 0, 1}, {2, 1, 3}}
+	if c.V() != 4 || c.E() != 8 || !reflect.DeepEqual(graphEdges(c.ToGraph()), expected) {
+		t.Fatalf("expected %v, got %v", expected, graphEdges(c.ToGraph()))
+	}
+
+	it := c.OutEdges(2)
+	if e, err := it.GetNext(); err != nil || e != (ds.Edge{From: 2, To: 0, Weight: 1}) {
+		t.Fatalf("unexpected edge %v", e)
+	}
+
+	it.GetNext()
+	if _, err := it.GetNext(); it.HasNext() || err == nil {
+		t.Fatal("expected an error for an exhausted iterator")
+	}
+
+	invalid := ds.NewArrayBag[ds.Edge]()
+	invalid.Add(ds.Edge{From: 0, To: 4})
+
+	if _, err := ds.NewCSRGraphFromEdges(4, true, invalid.CreateIterator()); err == nil {
+		t.Fatal("expected an error for an invalid vertex")
+	}
+
+	if _, err := ds.NewCSRGraphFromEdges(-1, true, edges.CreateIterator()); err == nil {
+		t.Fatal("expected an error for a negative number of vertices")
+	}
+}
+
+// newBenchmarkGraph returns a random directed graph with 100,000 vertices and
+// 1,000,000 edges.
+func newBenchmarkGraph() *ds.Graph {
+	return newRandomGraph(rand.New(rand.NewSource(21)), 100_000, 1_000_000, true, 1, 100)
+}
+
+// heapBytes returns the number of bytes allocated by build that are still in use.
+func heapBytes(build func() any) float64 {
+	var before, after runtime.MemStats
+
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	g := build()
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+	runtime.KeepAlive(g)
+
+	return float64(after.HeapAlloc) - float64(before.HeapAlloc)
+}
+
+func BenchmarkGraphMemory(b *testing.B) {
+	g := newBenchmarkGraph()
+
+	b.Run("Graph", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.ReportMetric(heapBytes(func() any { return newBenchmarkGraph() }), "heap-bytes")
+		}
+	})
+
+	b.Run("CSRGraph", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.ReportMetric(heapBytes(func() any { return ds.NewCSRGraph(g) }), "heap-bytes")
+		}
+	})
+}
+
+func BenchmarkGraphBreadthFirstSearch(b *testing.B) {
+	g := newBenchmarkGraph()
+
+	for name, view := range map[string]ds.GraphView{"Graph": g, "CSRGraph": ds.NewCSRGraph(g)} {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				ds.BreadthFirstSearch(view, 0, nil)
+			}
+		})
+	}
+}
+
+func BenchmarkGraphDijkstra(b *testing.B) {
+	g := newBenchmarkGraph()
+
+	for name, view := range map[string]ds.GraphView{"Graph": g, "CSRGraph": ds.NewCSRGraph(g)} {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				ds.Dijkstra(view, 0)
+			}
+		})
+	}
+}
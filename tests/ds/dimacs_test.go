@@ -0,0 +1,78 @@
+package ds_test
+
+import (
+	"bytes"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/welschma/godsa/ds"
+)
+
+func TestDIMACSRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(18))
+
+	for i := 0; i < 50; i++ {
+		g := newRandomGraph(r, 1+r.Intn(10), r.Intn(30), true, -5, 5)
+
+		var buffer bytes.Buffer
+		if err := g.WriteDIMACS(&buffer); err != nil {
+			t.Fatal(err)
+		}
+
+		read, err := ds.ReadDIMACS(&buffer)
+		if err != nil {
+			t.Fatalf("graph %d: %v", i, err)
+		}
+
+		if read.V() != g.V() || !read.Directed() || !reflect.DeepEqual(sortedEdges(read), sortedEdges(g)) {
+			t.Fatalf("graph %d: expected %v, got %v", i, sortedEdges(g), sortedEdges(read))
+		}
+	}
+}
+
+func TestReadDIMACS(t *testing.T) {
+	input := "c 9th DIMACS challenge\nc\np sp 3 3\na 1 2 7\n\na 2 3 -1\na\t3\t3\t0\n"
+
+	g, err := ds.ReadDIMACS(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []ds.Edge{{From: 0, To: 1, Weight: 7}, {From: 1, To: 2, Weight: -1}, {From: 2, To: 2, Weight: 0}}
+	if g.V() != 3 || !reflect.DeepEqual(sortedEdges(g), expected) {
+		t.Fatalf("expected %v, got %v", expected, sortedEdges(g))
+	}
+
+	// undirected edges become two arcs
+	var buffer bytes.Buffer
+	newTestGraph(2, false, [][3]int{{0, 1, 3}}).WriteDIMACS(&buffer)
+
+	if buffer.String() != "p sp 2 2\na 1 2 3\na 2 1 3\n" {
+		t.Fatalf("unexpected output %q", buffer.String())
+	}
+}
+
+func TestReadDIMACSErrors(t *testing.T) {
+	for _, tc := range []struct{ input, line string }{
+		{"", "line 1:"},
+		{"c only comments\n", "line 2:"},
+		{"a 1 2 3\n", "line 1:"},
+		{"p max 2 1\n", "line 1:"},
+		{"p sp 2\n", "line 1:"},
+		{"c huge\np sp 999999999999999999 0\n", "line 2:"},
+		{"p sp 2 1\np sp 2 1\n", "line 2:"},
+		{"p sp 2 1\na 1 2\n", "line 2:"},
+		{"p sp 2 1\na 1 3 1\n", "line 2:"},
+		{"p sp 2 1\na 0 1 1\n", "line 2:"},
+		{"p sp 2 1\nx 1 2 1\n", "line 2:"},
+		{"p sp 2 2\na 1 2 1\n", "line 3:"},
+	} {
+		_, err := ds.ReadDIMACS(strings.NewReader(tc.input))
+
+		if err == nil || !strings.HasPrefix(err.Error(), tc.line) {
+			t.Fatalf("input %q: expected an error starting with %q, got %v", tc.input, tc.line, err)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package ds_test
+
+import (
+	"bytes"
+	"math/rand"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/welschma/godsa/ds"
+)
+
+// sortedEdges returns the edges of the graph in increasing order.
+func sortedEdges(g *ds.Graph) []ds.Edge {
+	edges := g.Edges()
+
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].From != edges[j].From {
+			return edges[i].From < edges[j].From
+		}
+		if edges[i].To != edges[j].To {
+			return edges[i].To < edges[j].To
+		}
+		return edges[i].Weight < edges[j].Weight
+	})
+
+	return edges
+}
+
+func TestWriteDOT(t *testing.T) {
+	g := newTestGraph(3, true, [][3]int{{0, 1, 5}, {1, 2, -1}})
+
+	var buffer bytes.Buffer
+	if err := g.WriteDOT(&buffer, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "digraph \"G\" {\n  0;\n  1;\n  2;\n  0 -> 1 [label=5];\n  1 -> 2 [label=-1];\n}\n"
+	if buffer.String() != expected {
+		t.Fatalf("expected\n%s\ngot\n%s", expected, buffer.String())
+	}
+
+	g = newTestGraph(3, false, [][3]int{{1, 0, 2}, {2, 2, 1}})
+	buffer.Reset()
+	g.WriteDOT(&buffer, &ds.DOTOptions{Name: "tree", VertexLabels: []string{"a", "", `say "hi"`}})
+
+	expected = "graph \"tree\" {\n  0 [label=\"a\"];\n  1;\n  2 [label=\"say \\\"hi\\\"\"];\n  0 -- 1 [label=2];\n  2 -- 2 [label=1];\n}\n"
+	if buffer.String() != expected {
+		t.Fatalf("expected\n%s\ngot\n%s", expected, buffer.String())
+	}
+}
+
+func TestWriteDOTHighlight(t *testing.T) {
+	// parallel edges 0 - 1 with weights 4 and 1
+	g := newTestGraph(4, false, [][3]int{{0, 1, 4}, {0, 1, 1}, {1, 2, 2}, {2, 3, 3}, {0, 3, 9}})
+
+	var buffer bytes.Buffer
+	g.WriteDOT(&buffer, &ds.DOTOptions{HighlightPath: []int{0, 1, 2}})
+
+	for _, line := range strings.Split(buffer.String(), "\n") {
+		highlighted := strings.Contains(line, "color=red")
+		expected := line == "  0 [color=red, fontcolor=red];" || line == "  1 [color=red, fontcolor=red];" ||
+			line == "  2 [color=red, fontcolor=red];" || strings.HasPrefix(line, "  0 -- 1 [label=1,") ||
+			strings.HasPrefix(line, "  1 -- 2 [label=2,")
+
+		if highlighted != expected {
+			t.Fatalf("unexpected highlighting of %q in\n%s", line, buffer.String())
+		}
+	}
+
+	forest, _ := ds.Kruskal(g)
+	buffer.Reset()
+	g.WriteDOT(&buffer, &ds.DOTOptions{HighlightEdges: forest.Edges()})
+
+	if count := strings.Count(buffer.String(), "penwidth"); count != 3 {
+		t.Fatalf("expected 3 highlighted edges, got %d in\n%s", count, buffer.String())
+	}
+
+	if strings.Contains(buffer.String(), "0 -- 1 [label=4, color") {
+		t.Fatalf("the heavier parallel edge must not be highlighted\n%s", buffer.String())
+	}
+}
+
+func TestReadDOTRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(17))
+
+	for i := 0; i < 100; i++ {
+		directed := r.Intn(2) == 0
+		g := newRandomGraph(r, 1+r.Intn(10), r.Intn(30), directed, -5, 5)
+		labels := make([]string, g.V())
+
+		for v := range labels {
+			if r.Intn(2) == 0 {
+				labels[v] = strings.Repeat(`x"\ `, r.Intn(3))
+			}
+		}
+
+		var buffer bytes.Buffer
+		if err := g.WriteDOT(&buffer, &ds.DOTOptions{VertexLabels: labels, HighlightPath: []int{0, g.V() - 1}}); err != nil {
+			t.Fatal(err)
+		}
+
+		read, readLabels, err := ds.ReadDOT(&buffer)
+		if err != nil {
+			t.Fatalf("graph %d: %v", i, err)
+		}
+
+		if read.V() != g.V() || read.E() != g.E() || read.Directed() != directed {
+			t.Fatalf("graph %d: expected %d vertices and %d edges, got %d and %d", i, g.V(), g.E(), read.V(), read.E())
+		}
+
+		if !reflect.DeepEqual(sortedEdges(read), sortedEdges(g)) || !reflect.DeepEqual(readLabels, labels) {
+			t.Fatalf("graph %d: expected %v with labels %q, got %v with labels %q", i, sortedEdges(g), labels, sortedEdges(read), readLabels)
+		}
+	}
+}
+
+func TestReadDOT(t *testing.T) {
+	input := `// hand written
+digraph {
+	0 -> 2 [label="7" color=blue]
+	2 -> 1; 3
+}
+`
+
+	g, labels, err := ds.ReadDOT(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []ds.Edge{{From: 0, To: 2, Weight: 7}, {From: 2, To: 1, Weight: 1}}
+	if g.V() != 4 || !g.Directed() || !reflect.DeepEqual(sortedEdges(g), expected) || len(labels) != 4 {
+		t.Fatalf("unexpected graph with edges %v", g.Edges())
+	}
+}
+
+func TestReadDOTErrors(t *testing.T) {
+	for _, tc := range []struct{ input, line string }{
+		{"", "line 1:"},
+		{"strict graph {}", "line 1:"},
+		{"graph G\n", "line 2:"},
+		{"graph {\n0 -- 1\n", "line 3:"},
+		{"graph {\n0 -> 1\n}", "line 2:"},
+		{"digraph {\n\n0 -- 1\n}", "line 3:"},
+		{"graph {\na -- 1\n}", "line 2:"},
+		{"graph {\n0 -- 1 [label=x]\n}", "line 2:"},
+		{"graph {\n0 -- 1 [label=\"1]\n}", "line 2:"},
+		{"graph {\n0 [label]\n}", "line 2:"},
+		{"graph {\n0 -- -1\n}", "line 2:"},
+		{"graph {\n0 -- 1\n99999999999999999\n}", "line 3:"},
+		{"graph {\n1048576 [label=x]\n}", "line 2:"},
+		{"graph {\n0 # 1\n}", "line 2:"},
+		{"graph {\n}\n}", "line 3:"},
+	} {
+		_, _, err := ds.ReadDOT(strings.NewReader(tc.input))
+
+		if err == nil || !strings.HasPrefix(err.Error(), tc.line) {
+			t.Fatalf("input %q: expected an error starting with %q, got %v", tc.input, tc.line, err)
+		}
+	}
+}
@@ -2,6 +2,10 @@ package ds_test
 
 import (
 	"bytes"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/welschma/godsa/ds"
@@ -32,3 +36,215 @@ func TestGraph(t *testing.T) {
 		t.Errorf("Expected '%s', got '%s'", expected, buffer.String())
 	}
 }
+
+// graphEdges returns every adjacency entry of the graph as {x, y, weight}, as read
+// back from the output of Graph.Write.
+func graphEdges(g *ds.Graph) [][3]int {
+	var buffer bytes.Buffer
+	g.Write(&buffer)
+
+	edges := [][3]int{}
+	lines := strings.Split(buffer.String(), "\n")
+
+	for _, line := range lines[1:] {
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		x, _ := strconv.Atoi(parts[0])
+		for _, entry := range strings.Split(parts[1], ")") {
+			var y, w int
+			if n, _ := fmt.Sscanf(strings.TrimSpace(entry), "(%d, %d", &y, &w); n == 2 {
+				edges = append(edges, [3]int{x, y, w})
+			}
+		}
+	}
+
+	return edges
+}
+
+func TestGraphEdges(t *testing.T) {
+	g := ds.NewGraph(3, false)
+	g.AddEdge(0, 1, 5)
+	g.AddEdge(2, 1, 7)
+	g.AddEdge(2, 2, 1)
+
+	want := map[ds.Edge]bool{{From: 0, To: 1, Weight: 5}: true, {From: 1, To: 2, Weight: 7}: true, {From: 2, To: 2, Weight: 1}: true}
+	edges := g.Edges()
+
+	if len(edges) != len(want) {
+		t.Fatalf("expected %d edges, got %v", len(want), edges)
+	}
+
+	for _, e := range edges {
+		if !want[e] {
+			t.Errorf("unexpected edge %v", e)
+		}
+	}
+
+	directed := ds.NewGraph(2, true)
+	directed.AddEdge(1, 0, 3)
+
+	if got := directed.Edges(); len(got) != 1 || got[0] != (ds.Edge{From: 1, To: 0, Weight: 3}) {
+		t.Errorf("expected a single edge 1 -> 0, got %v", got)
+	}
+}
+
+func TestGraphAddEdgeErrors(t *testing.T) {
+	g := ds.NewGraph(2, true)
+
+	for _, e := range [][2]int{{-1, 0}, {0, 2}, {2, 2}} {
+		if err := g.AddEdge(e[0], e[1], 1); err == nil {
+			t.Fatalf("expected an error for edge %v", e)
+		}
+	}
+
+	if g.E() != 0 {
+		t.Fatalf("expected no edges, got %d", g.E())
+	}
+}
+
+func TestGraphEdgeQueries(t *testing.T) {
+	for _, directed := range []bool{true, false} {
+		g := newTestGraph(4, directed, [][3]int{{0, 1, 5}, {0, 1, 7}, {1, 2, 3}, {2, 2, 1}})
+
+		if w, err := g.Weight(0, 1); err != nil || w != 7 {
+			t.Fatalf("expected weight 7, got %d", w)
+		}
+
+		if !g.HasEdge(1, 2) || g.HasEdge(0, 2) || g.HasEdge(0, 4) || g.HasEdge(-1, 0) {
+			t.Fatal("unexpected result of HasEdge")
+		}
+
+		if g.HasEdge(1, 0) != !directed {
+			t.Fatalf("directed %v: unexpected reverse edge", directed)
+		}
+
+		if _, err := g.Weight(0, 3); err == nil {
+			t.Fatal("expected an error for a missing edge")
+		}
+
+		if _, err := g.Weight(0, 4); err == nil {
+			t.Fatal("expected an error for an invalid vertex")
+		}
+
+		// vertex, out-degree and in-degree if directed, degree if undirected
+		for _, tc := range [][4]int{{0, 2, 0, 2}, {1, 1, 2, 3}, {2, 1, 2, 3}, {3, 0, 0, 0}} {
+			out, in := tc[1], tc[2]
+			if !directed {
+				out, in = tc[3], tc[3]
+			}
+
+			if d, err := g.Degree(tc[0]); err != nil || d != out {
+				t.Fatalf("directed %v: expected degree %d of vertex %d, got %d", directed, out, tc[0], d)
+			}
+
+			if d, err := g.InDegree(tc[0]); err != nil || d != in {
+				t.Fatalf("directed %v: expected in-degree %d of vertex %d, got %d", directed, in, tc[0], d)
+			}
+		}
+
+		if _, err := g.Degree(4); err == nil {
+			t.Fatal("expected an error for an invalid vertex")
+		}
+
+		if _, err := g.InDegree(-1); err == nil {
+			t.Fatal("expected an error for an invalid vertex")
+		}
+	}
+}
+
+func TestGraphNeighbors(t *testing.T) {
+	g := newTestGraph(3, true, [][3]int{{0, 1, 5}, {0, 2, 3}, {1, 2, 1}})
+
+	it, err := g.Neighbors(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	edges := []ds.Edge{}
+	for it.HasNext() {
+		e, err := it.GetNext()
+		if err != nil {
+			t.Fatal(err)
+		}
+		edges = append(edges, e)
+	}
+
+	expected := []ds.Edge{{From: 0, To: 2, Weight: 3}, {From: 0, To: 1, Weight: 5}}
+	if !reflect.DeepEqual(edges, expected) {
+		t.Fatalf("expected %v, got %v", expected, edges)
+	}
+
+	if _, err := it.GetNext(); err == nil {
+		t.Fatal("expected an error for an exhausted iterator")
+	}
+
+	if _, err := g.Neighbors(3); err == nil {
+		t.Fatal("expected an error for an invalid vertex")
+	}
+}
+
+func TestGraphRemoveEdge(t *testing.T) {
+	g := newTestGraph(3, false, [][3]int{{0, 1, 5}, {1, 0, 7}, {1, 2, 3}, {2, 2, 1}})
+
+	if err := g.RemoveEdge(0, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := g.RemoveEdge(2, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []ds.Edge{{From: 0, To: 1, Weight: 5}, {From: 1, To: 2, Weight: 3}}
+	if g.E() != 4 || !reflect.DeepEqual(g.Edges(), expected) {
+		t.Fatalf("expected %v with 4 entries, got %v with %d", expected, g.Edges(), g.E())
+	}
+
+	if err := g.RemoveEdge(2, 1); err != nil || g.E() != 2 || g.HasEdge(1, 2) {
+		t.Fatalf("expected the edge 1 - 2 to be removed, got %v", err)
+	}
+
+	for _, e := range [][2]int{{0, 2}, {2, 2}, {0, 3}, {-1, 0}} {
+		if err := g.RemoveEdge(e[0], e[1]); err == nil {
+			t.Fatalf("expected an error for edge %v", e)
+		}
+	}
+
+	g = newTestGraph(2, true, [][3]int{{0, 1, 1}, {1, 0, 2}})
+	if err := g.RemoveEdge(1, 0); err != nil || g.E() != 1 || !g.HasEdge(0, 1) {
+		t.Fatalf("expected only the edge 1 -> 0 to be removed, got %v", err)
+	}
+}
+
+func TestGraphAddRemoveVertex(t *testing.T) {
+	g := newTestGraph(4, true, [][3]int{{0, 1, 1}, {1, 2, 2}, {2, 3, 3}, {3, 1, 4}, {0, 3, 5}})
+
+	if v := g.AddVertex(); v != 4 || g.V() != 5 {
+		t.Fatalf("expected new vertex 4, got %d", v)
+	}
+
+	if err := g.AddEdge(4, 3, 6); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := g.RemoveVertex(1); err != nil {
+		t.Fatal(err)
+	}
+
+	// 2 -> 1, 0 -> 2, 3 -> 2 after renumbering
+	expected := [][3]int{{0, 2, 5}, {1, 2, 3}, {3, 2, 6}}
+	if g.V() != 4 || g.E() != 3 || !reflect.DeepEqual(graphEdges(g), expected) {
+		t.Fatalf("expected %v, got %v", expected, graphEdges(g))
+	}
+
+	if err := g.RemoveVertex(4); err == nil {
+		t.Fatal("expected an error for an invalid vertex")
+	}
+
+	g = newTestGraph(3, false, [][3]int{{0, 1, 1}, {1, 2, 2}, {2, 2, 3}})
+	if err := g.RemoveVertex(2); err != nil || g.V() != 2 || g.E() != 2 || !g.HasEdge(1, 0) {
+		t.Fatalf("expected only the edge 0 - 1 to remain, got %v", graphEdges(g))
+	}
+}
@@ -0,0 +1,147 @@
+package ds_test
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/welschma/godsa/ds"
+)
+
+func TestReadGraphRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(16))
+
+	for i := 0; i < 100; i++ {
+		directed := r.Intn(2) == 0
+		g := newRandomGraph(r, 1+r.Intn(10), r.Intn(30), directed, -5, 5)
+
+		var buffer bytes.Buffer
+		g.Write(&buffer)
+		written := buffer.String()
+
+		read, err := ds.ReadGraph(&buffer, directed)
+		if err != nil {
+			t.Fatalf("graph %d: %v\n%s", i, err, written)
+		}
+
+		buffer.Reset()
+		read.Write(&buffer)
+
+		if buffer.String() != written || read.Directed() != directed {
+			t.Fatalf("graph %d: expected\n%s\ngot\n%s", i, written, buffer.String())
+		}
+	}
+}
+
+func TestReadGraphEdgeList(t *testing.T) {
+	input := "4 3\n0 1 5\n\n1 2 -1\n3 3 2\n"
+
+	g, err := ds.ReadGraph(strings.NewReader(input), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if g.V() != 4 || g.E() != 6 {
+		t.Fatalf("expected 4 vertices and 6 adjacency entries, got %d and %d", g.V(), g.E())
+	}
+
+	expected := []ds.Edge{{From: 0, To: 1, Weight: 5}, {From: 1, To: 2, Weight: -1}, {From: 3, To: 3, Weight: 2}}
+	if edges := g.Edges(); len(edges) != 3 || edges[0] != expected[0] || edges[1] != expected[1] || edges[2] != expected[2] {
+		t.Fatalf("expected edges %v, got %v", expected, edges)
+	}
+
+	g, err = ds.ReadGraph(strings.NewReader("3 0\n"), true)
+	if err != nil || g.V() != 3 || g.E() != 0 {
+		t.Fatalf("expected an empty graph, got %v", err)
+	}
+}
+
+func TestReadGraphErrors(t *testing.T) {
+	for _, tc := range []struct {
+		input    string
+		directed bool
+		line     string
+	}{
+		{"", true, "line 1:"},
+		{"3\n", true, "line 1:"},
+		{"-1 0\n", true, "line 1:"},
+		{"999999999999999999 0\n", true, "line 1:"},
+		{"\n1048577 0\n", true, "line 2:"},
+		{"2 1\n0 1\n", true, "line 2:"},
+		{"2 2\n0 1 1\n\n0 2 1\n", true, "line 4:"},
+		{"2 2\n0 1 1\n", true, "line 3:"},
+		{"2 1\n0 1 1\n1 0 1\n", true, "line 3:"},
+		{"2 0\n0 1 1\n", true, "line 2:"},
+		{"2 1\n0: (1, 1) \n", true, "line 3:"},
+		{"2 1\n0: (1, 1) \n2: \n", true, "line 3:"},
+		{"2 1\n0: (1 1) \n1: \n", true, "line 2:"},
+		{"2 1\n0: (1, 1 \n1: \n", true, "line 2:"},
+		{"2 1\n0: (5, 1) \n1: \n", true, "line 2:"},
+		{"2 2\n0: (1, 1) \n1: \n", true, "line 3:"},
+		{"2 1\n0: (1, 1) \n1: \n1 2\n", true, "line 4:"},
+		{"3 3\n0: (1, 1) \n1: (0, 1) (2, 1) \n2: \n", false, "line 3:"},
+		{"2 2\n0: (1, 1) \n1: (0, 2) \n", false, "line 2:"},
+		{"0 0\n0: \n", false, "line 2:"},
+	} {
+		_, err := ds.ReadGraph(strings.NewReader(tc.input), tc.directed)
+
+		if err == nil || !strings.HasPrefix(err.Error(), tc.line) {
+			t.Fatalf("input %q: expected an error starting with %q, got %v", tc.input, tc.line, err)
+		}
+	}
+}
+
+// allocatedBytes returns the number of bytes allocated by f, including garbage.
+func allocatedBytes(f func()) uint64 {
+	var before, after runtime.MemStats
+
+	runtime.ReadMemStats(&before)
+	f()
+	runtime.ReadMemStats(&after)
+
+	return after.TotalAlloc - before.TotalAlloc
+}
+
+func TestReadersRejectHugeHeadersCheaply(t *testing.T) {
+	// each input claims 2^26 vertices in a few bytes and has no edges
+	for _, tc := range []struct {
+		name  string
+		input string
+		read  func(r io.Reader) error
+	}{
+		{"ReadGraph", "67108864 0\n", func(r io.Reader) error {
+			_, err := ds.ReadGraph(r, true)
+			return err
+		}},
+		{"ReadDOT", "digraph {\n67108863\n}\n", func(r io.Reader) error {
+			_, _, err := ds.ReadDOT(r)
+			return err
+		}},
+		{"ReadDIMACS", "p sp 67108864 0\n", func(r io.Reader) error {
+			_, err := ds.ReadDIMACS(r)
+			return err
+		}},
+		{"ReadMatrixMarket", "%%MatrixMarket matrix coordinate integer general\n67108864 67108864 0\n", func(r io.Reader) error {
+			_, err := ds.ReadMatrixMarket(r)
+			return err
+		}},
+		{"ReadSNAP", "# Nodes: 67108864 Edges: 0\n", func(r io.Reader) error {
+			_, err := ds.ReadSNAP(r, true)
+			return err
+		}},
+	} {
+		var err error
+		allocated := allocatedBytes(func() { err = tc.read(strings.NewReader(tc.input)) })
+
+		if err == nil {
+			t.Fatalf("%s: expected an error for a huge header", tc.name)
+		}
+
+		if allocated > 1<<20 {
+			t.Fatalf("%s: rejecting a huge header allocated %d bytes", tc.name, allocated)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package ds_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/welschma/godsa/ds"
+)
+
+// newTestGraph builds a graph from the given edges, each given as {x, y, weight}.
+func newTestGraph(v int, directed bool, edges [][3]int) *ds.Graph {
+	g := ds.NewGraph(v, directed)
+
+	for _, e := range edges {
+		g.AddEdge(e[0], e[1], e[2])
+	}
+
+	return g
+}
+
+func TestBreadthFirstSearch(t *testing.T) {
+	// 0 - 1 - 2 - 3, 0 - 4 - 3, 5 isolated
+	g := newTestGraph(6, false, [][3]int{{0, 1, 1}, {1, 2, 1}, {2, 3, 1}, {0, 4, 1}, {4, 3, 1}})
+
+	tree, err := ds.BreadthFirstSearch(g, 0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantLevels := []int{0, 1, 2, 2, 1, -1}
+	if got := tree.Levels(); !reflect.DeepEqual(got, wantLevels) {
+		t.Errorf("levels: want %v, got %v", wantLevels, got)
+	}
+
+	if tree.HasPathTo(5) || tree.PathTo(5) != nil {
+		t.Error("isolated vertex should not be reachable")
+	}
+
+	if got := tree.PathTo(3); !reflect.DeepEqual(got, []int{0, 4, 3}) {
+		t.Errorf("path to 3: want [0 4 3], got %v", got)
+	}
+
+	if _, err := ds.BreadthFirstSearch(g, 6, nil); err == nil {
+		t.Error("expected error for source out of bounds")
+	}
+}
+
+func TestBreadthFirstSearchVisitor(t *testing.T) {
+	g := newTestGraph(4, false, [][3]int{{0, 1, 1}, {0, 2, 1}, {1, 2, 1}, {2, 3, 1}})
+	discovered, treeEdges, nonTreeEdges, finished := 0, 0, 0, 0
+
+	visitor := &ds.GraphVisitor{
+		DiscoverVertex: func(v int) bool { discovered++; return true },
+		FinishVertex:   func(v int) bool { finished++; return true },
+		TreeEdge:       func(x, y int) bool { treeEdges++; return true },
+		NonTreeEdge:    func(x, y int) bool { nonTreeEdges++; return true },
+	}
+
+	tree, _ := ds.BreadthFirstSearch(g, 0, visitor)
+
+	if discovered != 4 || finished != 4 || treeEdges != 3 || nonTreeEdges != 1 {
+		t.Errorf("unexpected event counts: discovered %d, finished %d, tree %d, non-tree %d",
+			discovered, finished, treeEdges, nonTreeEdges)
+	}
+
+	if tree.Stopped() {
+		t.Error("search should not be stopped")
+	}
+}
+
+func TestDepthFirstSearchEvents(t *testing.T) {
+	// 0 -> 1 -> 2 -> 0 is a cycle, 0 -> 3 and 1 -> 3
+	g := newTestGraph(4, true, [][3]int{{0, 1, 1}, {1, 2, 1}, {2, 0, 1}, {0, 3, 1}, {1, 3, 1}})
+	backEdges := [][2]int{}
+	finishOrder := []int{}
+
+	visitor := &ds.GraphVisitor{
+		BackEdge:     func(x, y int) bool { backEdges = append(backEdges, [2]int{x, y}); return true },
+		FinishVertex: func(v int) bool { finishOrder = append(finishOrder, v); return true },
+	}
+
+	tree, err := ds.DepthFirstSearch(g, 0, visitor)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !reflect.DeepEqual(backEdges, [][2]int{{2, 0}}) {
+		t.Errorf("back edges: want [[2 0]], got %v", backEdges)
+	}
+
+	if len(finishOrder) != 4 || finishOrder[3] != 0 {
+		t.Errorf("source should finish last, got finish order %v", finishOrder)
+	}
+
+	for v := 0; v < 4; v++ {
+		if !tree.Visited(v) {
+			t.Errorf("vertex %d should be visited", v)
+		}
+	}
+}
+
+func TestDepthFirstSearchUndirected(t *testing.T) {
+	// a tree has no back edges, a triangle has exactly one
+	tree := newTestGraph(4, false, [][3]int{{0, 1, 1}, {1, 2, 1}, {1, 3, 1}})
+	triangle := newTestGraph(3, false, [][3]int{{0, 1, 1}, {1, 2, 1}, {2, 0, 1}})
+
+	for g, want := range map[*ds.Graph]int{tree: 0, triangle: 1} {
+		backEdges := 0
+		visitor := &ds.GraphVisitor{BackEdge: func(x, y int) bool { backEdges++; return true }}
+
+		ds.DepthFirstSearch(g, 0, visitor)
+
+		if backEdges != want {
+			t.Errorf("expected %d back edges, got %d", want, backEdges)
+		}
+	}
+}
+
+func TestDepthFirstSearchEarlyTermination(t *testing.T) {
+	g := ds.NewGraph(100, true)
+	for i := 0; i < 99; i++ {
+		g.AddEdge(i, i+1, 1)
+	}
+
+	visitor := &ds.GraphVisitor{DiscoverVertex: func(v int) bool { return v != 10 }}
+	tree, _ := ds.DepthFirstSearch(g, 0, visitor)
+
+	if !tree.Stopped() {
+		t.Error("search should be stopped")
+	}
+
+	if tree.Visited(11) || !tree.Visited(10) {
+		t.Error("search should stop right after discovering vertex 10")
+	}
+}
+
+func TestDepthFirstSearchDeepGraph(t *testing.T) {
+	n := 1000000
+	g := ds.NewGraph(n, true)
+	for i := 0; i < n-1; i++ {
+		g.AddEdge(i, i+1, 1)
+	}
+
+	tree, _ := ds.DepthFirstSearch(g, 0, nil)
+
+	if tree.Level(n-1) != n-1 {
+		t.Errorf("expected level %d, got %d", n-1, tree.Level(n-1))
+	}
+}
+
+func TestDepthFirstSearchAll(t *testing.T) {
+	g := newTestGraph(5, true, [][3]int{{0, 1, 1}, {2, 3, 1}, {3, 4, 1}})
+	tree := ds.DepthFirstSearchAll(g, nil)
+
+	wantParents := []int{-1, 0, -1, 2, 3}
+	if got := tree.Parents(); !reflect.DeepEqual(got, wantParents) {
+		t.Errorf("parents: want %v, got %v", wantParents, got)
+	}
+
+	if got := tree.PathTo(4); !reflect.DeepEqual(got, []int{2, 3, 4}) {
+		t.Errorf("path to 4: want [2 3 4], got %v", got)
+	}
+}
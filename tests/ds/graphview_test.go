@@ -0,0 +1,221 @@
+package ds_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/welschma/godsa/ds"
+)
+
+// gridMaze is an implicit undirected graph whose vertices are the cells of a maze,
+// numbered row by row. Open cells are connected to their open neighbors, walls have
+// no edges. Edges are computed on demand.
+type gridMaze struct {
+	rows []string
+}
+
+func (m *gridMaze) V() int {
+	return len(m.rows) * len(m.rows[0])
+}
+
+func (m *gridMaze) Directed() bool {
+	return false
+}
+
+func (m *gridMaze) OutEdges(x int) ds.Iterator[ds.Edge] {
+	width := len(m.rows[0])
+	r, c := x/width, x%width
+	edges := ds.NewArrayBag[ds.Edge]()
+
+	if m.rows[r][c] != '#' {
+		for _, d := range [][2]int{{-1, 0}, {0, -1}, {0, 1}, {1, 0}} {
+			nr, nc := r+d[0], c+d[1]
+
+			if nr >= 0 && nr < len(m.rows) && nc >= 0 && nc < width && m.rows[nr][nc] != '#' {
+				edges.Add(ds.Edge{From: x, To: nr*width + nc, Weight: 1})
+			}
+		}
+	}
+
+	return edges.CreateIterator()
+}
+
+// doublingStates is an implicit directed state space on the numbers 0 to n - 1,
+// where x can be incremented or doubled as long as the result stays below n. If
+// wrap is true, doubling is taken modulo n instead, which creates cycles.
+type doublingStates struct {
+	n    int
+	wrap bool
+}
+
+func (s *doublingStates) V() int {
+	return s.n
+}
+
+func (s *doublingStates) Directed() bool {
+	return true
+}
+
+func (s *doublingStates) OutEdges(x int) ds.Iterator[ds.Edge] {
+	edges := ds.NewArrayBag[ds.Edge]()
+
+	doubled := 2 * x
+	if s.wrap {
+		doubled %= s.n
+	}
+
+	for _, y := range []int{x + 1, doubled} {
+		if y != x && y < s.n {
+			edges.Add(ds.Edge{From: x, To: y, Weight: y - x})
+		}
+	}
+
+	return edges.CreateIterator()
+}
+
+// materialize copies a graph view into a Graph.
+func materialize(view ds.GraphView) *ds.Graph {
+	g := ds.NewGraph(view.V(), view.Directed())
+
+	for x := 0; x < view.V(); x++ {
+		for it := view.OutEdges(x); it.HasNext(); {
+			e, _ := it.GetNext()
+
+			if view.Directed() || e.From < e.To {
+				g.AddEdge(e.From, e.To, e.Weight)
+			}
+		}
+	}
+
+	return g
+}
+
+var testMaze = &gridMaze{rows: []string{
+	"..#....",
+	".##.##.",
+	"...#...",
+	"#.....#",
+	"..#.#..",
+}}
+
+func TestGraphViewMaze(t *testing.T) {
+	g := materialize(testMaze)
+	exit := testMaze.V() - 1
+
+	bfs, err := ds.BreadthFirstSearch(testMaze, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sp, err := ds.Dijkstra(testMaze, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bfs.HasPathTo(exit) || bfs.Level(exit) != 10 || sp.DistTo(exit) != 10 {
+		t.Fatalf("expected the exit at distance 10, got %d and %d", bfs.Level(exit), sp.DistTo(exit))
+	}
+
+	if path := bfs.PathTo(exit); len(path) != 11 || path[0] != 0 || path[10] != exit {
+		t.Fatalf("unexpected path %v", path)
+	}
+
+	if bfs.HasPathTo(2) {
+		t.Fatal("expected no path into a wall")
+	}
+
+	mazeForest, _ := ds.Kruskal(testMaze)
+	graphForest, _ := ds.Kruskal(g)
+	primForest, _ := ds.EagerPrim(testMaze)
+
+	if mazeForest.Weight() != graphForest.Weight() || mazeForest.Components() != graphForest.Components() ||
+		primForest.Weight() != graphForest.Weight() {
+		t.Fatalf("spanning forests differ: %d and %d", mazeForest.Weight(), graphForest.Weight())
+	}
+
+	b, err := ds.Bipartite(testMaze)
+	if err != nil || !b.IsBipartite() {
+		t.Fatalf("expected a grid to be bipartite, got %v", err)
+	}
+
+	mazeMatching, _ := ds.HopcroftKarp(testMaze)
+	graphMatching, _ := ds.HopcroftKarp(g)
+
+	if mazeMatching.Size() != graphMatching.Size() {
+		t.Fatalf("expected a matching of size %d, got %d", graphMatching.Size(), mazeMatching.Size())
+	}
+
+	mazeBC, _ := ds.HopcroftTarjan(testMaze)
+	graphBC, _ := ds.HopcroftTarjan(g)
+
+	if !reflect.DeepEqual(mazeBC.ArticulationPoints(), graphBC.ArticulationPoints()) || mazeBC.Count() != graphBC.Count() {
+		t.Fatalf("expected articulation points %v, got %v", graphBC.ArticulationPoints(), mazeBC.ArticulationPoints())
+	}
+
+	mazeFlow, _ := ds.Dinic(testMaze, 0, exit)
+	graphFlow, _ := ds.EdmondsKarp(g, 0, exit)
+
+	// the exit has a single open neighbor
+	if mazeFlow.Value() != 1 || graphFlow.Value() != 1 {
+		t.Fatalf("expected a flow of 1 to the exit, got %d and %d", mazeFlow.Value(), graphFlow.Value())
+	}
+
+	mazeAPSP := ds.Johnson(testMaze)
+	graphAPSP := ds.FloydWarshall(g)
+
+	for x := 0; x < g.V(); x++ {
+		for y := 0; y < g.V(); y++ {
+			if mazeAPSP.Dist(x, y) != graphAPSP.Dist(x, y) {
+				t.Fatalf("distances from %d to %d differ", x, y)
+			}
+		}
+	}
+}
+
+func TestGraphViewStateSpace(t *testing.T) {
+	dag := &doublingStates{n: 20}
+
+	order, err := ds.LexicographicTopologicalSort(dag)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected, _ := ds.LexicographicTopologicalSort(materialize(dag))
+	if !reflect.DeepEqual(order, expected) {
+		t.Fatalf("expected %v, got %v", expected, order)
+	}
+
+	if cycle, err := ds.FindCycle(dag); cycle != nil || err != nil {
+		t.Fatalf("expected no cycle, got %v", cycle)
+	}
+
+	// 1 -> 2 -> 4 -> 8 -> 16 takes 4 steps, its weight is the difference 15
+	sp, _ := ds.BellmanFord(dag, 1)
+	if sp.DistTo(16) != 15 {
+		t.Fatalf("expected distance 15, got %d", sp.DistTo(16))
+	}
+
+	cyclic := &doublingStates{n: 11, wrap: true}
+
+	if _, err := ds.TopologicalSort(cyclic); err == nil {
+		t.Fatal("expected an error for a cyclic state space")
+	}
+
+	for name, algorithm := range sccAlgorithms {
+		scc, err := algorithm(cyclic)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected, _ := algorithm(materialize(cyclic))
+
+		// 2x mod 11 is only 0 for x = 0, so no state leads back to 0
+		if scc.Count() != 2 || expected.Count() != 2 || scc.StronglyConnected(0, 1) || !scc.StronglyConnected(1, 10) {
+			t.Fatalf("%s: unexpected components %v", name, scc.Components())
+		}
+
+		if c := scc.Condensation(nil); c.E() != 1 || !c.HasEdge(scc.ID(0), scc.ID(1)) {
+			t.Fatalf("%s: unexpected condensation", name)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package ds_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/welschma/godsa/ds"
+)
+
+func TestHeapEmpty(t *testing.T) {
+	h := ds.NewHeap[int](0, compareInt)
+
+	if !h.IsEmpty() {
+		t.Error("new heap should be empty")
+	}
+
+	if _, err := h.Peek(); err == nil {
+		t.Error("expected error when peeking into empty heap")
+	}
+
+	if _, err := h.Extract(); err == nil {
+		t.Error("expected error when extracting from empty heap")
+	}
+}
+
+func TestHeapMaxOrder(t *testing.T) {
+	h := ds.NewHeap[int](2, compareInt)
+	values := rand.New(rand.NewSource(1)).Perm(100)
+
+	for _, v := range values {
+		h.Insert(v)
+	}
+
+	if h.Size() != 100 {
+		t.Fatalf("expected size 100, got %d", h.Size())
+	}
+
+	top, err := h.Peek()
+	if err != nil || top != 99 {
+		t.Errorf("peek: expected 99, got %d (%v)", top, err)
+	}
+
+	for want := 99; want >= 0; want-- {
+		got, err := h.Extract()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != want {
+			t.Fatalf("extract: expected %d, got %d", want, got)
+		}
+	}
+
+	if !h.IsEmpty() {
+		t.Error("heap should be empty after extracting all elements")
+	}
+}
+
+func TestHeapMinOrder(t *testing.T) {
+	h := ds.NewMinHeap[int](0, compareInt)
+
+	for _, v := range []int{5, 3, 8, 1, 9, 1, 4} {
+		h.Insert(v)
+	}
+
+	want := []int{1, 1, 3, 4, 5, 8, 9}
+	for _, w := range want {
+		got, err := h.Extract()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != w {
+			t.Fatalf("extract: expected %d, got %d", w, got)
+		}
+	}
+}
+
+func TestHeapHeapify(t *testing.T) {
+	data := rand.New(rand.NewSource(2)).Perm(50)
+	original := append([]int{}, data...)
+
+	h := ds.NewMinHeap[int](0, compareInt)
+	h.Heapify(data)
+
+	if h.Size() != len(data) {
+		t.Fatalf("expected size %d, got %d", len(data), h.Size())
+	}
+
+	for i := range data {
+		if data[i] != original[i] {
+			t.Fatal("heapify should not modify the given slice")
+		}
+	}
+
+	sort.Ints(original)
+	for _, want := range original {
+		got, _ := h.Extract()
+		if got != want {
+			t.Fatalf("extract: expected %d, got %d", want, got)
+		}
+	}
+
+	// inserting after heapify must still grow the underlying storage
+	h.Heapify([]int{3, 2, 1})
+	h.Insert(0)
+
+	if got, _ := h.Peek(); got != 0 {
+		t.Errorf("peek: expected 0, got %d", got)
+	}
+}
@@ -0,0 +1,144 @@
+package ds_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/welschma/godsa/ds"
+)
+
+func TestIndexedPriorityQueueEmpty(t *testing.T) {
+	pq := ds.NewIndexedPriorityQueue[string, int](0, compareInt)
+
+	if !pq.IsEmpty() {
+		t.Error("new priority queue should be empty")
+	}
+
+	if _, _, err := pq.PeekMin(); err == nil {
+		t.Error("expected error when peeking into empty priority queue")
+	}
+
+	if _, _, err := pq.ExtractMin(); err == nil {
+		t.Error("expected error when extracting from empty priority queue")
+	}
+
+	if err := pq.Delete("a"); err == nil {
+		t.Error("expected error when deleting a missing key")
+	}
+
+	if err := pq.ChangePriority("a", 1); err == nil {
+		t.Error("expected error when changing the priority of a missing key")
+	}
+}
+
+func TestIndexedPriorityQueueInsertExtract(t *testing.T) {
+	pq := ds.NewIndexedPriorityQueue[string, int](1, compareInt)
+
+	for key, priority := range map[string]int{"c": 3, "a": 1, "e": 5, "b": 2, "d": 4} {
+		if err := pq.Insert(key, priority); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if err := pq.Insert("a", 10); err == nil {
+		t.Error("expected error when inserting a duplicate key")
+	}
+
+	if !pq.Contains("d") || pq.Contains("z") {
+		t.Error("contains reports wrong membership")
+	}
+
+	for _, want := range []string{"a", "b", "c", "d", "e"} {
+		key, _, err := pq.ExtractMin()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if key != want {
+			t.Fatalf("extract: expected %s, got %s", want, key)
+		}
+	}
+}
+
+func TestIndexedPriorityQueueChangePriority(t *testing.T) {
+	pq := ds.NewIndexedPriorityQueue[int, int](0, compareInt)
+
+	for i := 0; i < 10; i++ {
+		pq.Insert(i, 10*i)
+	}
+
+	if err := pq.DecreaseKey(7, -1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := pq.IncreaseKey(0, 100); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := pq.DecreaseKey(3, 1000); err == nil {
+		t.Error("expected error when decreasing to a greater priority")
+	}
+
+	if err := pq.IncreaseKey(3, -1000); err == nil {
+		t.Error("expected error when increasing to a smaller priority")
+	}
+
+	if err := pq.Delete(5); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if p, ok := pq.Priority(7); !ok || p != -1 {
+		t.Errorf("priority of key 7: expected -1, got %d", p)
+	}
+
+	want := []int{7, 1, 2, 3, 4, 6, 8, 9, 0}
+	for _, w := range want {
+		key, _, _ := pq.ExtractMin()
+		if key != w {
+			t.Fatalf("extract: expected %d, got %d", w, key)
+		}
+	}
+
+	if !pq.IsEmpty() {
+		t.Error("priority queue should be empty")
+	}
+}
+
+func TestIndexedPriorityQueueRandomized(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	pq := ds.NewIndexedPriorityQueue[int, int](0, compareInt)
+	priorities := map[int]int{}
+
+	for i := 0; i < 1000; i++ {
+		key := r.Intn(200)
+		priority := r.Intn(1000)
+
+		switch {
+		case !pq.Contains(key):
+			pq.Insert(key, priority)
+			priorities[key] = priority
+		case r.Intn(3) == 0:
+			pq.Delete(key)
+			delete(priorities, key)
+		default:
+			pq.ChangePriority(key, priority)
+			priorities[key] = priority
+		}
+	}
+
+	if pq.Size() != len(priorities) {
+		t.Fatalf("expected size %d, got %d", len(priorities), pq.Size())
+	}
+
+	last := -1
+	for !pq.IsEmpty() {
+		key, priority, _ := pq.ExtractMin()
+
+		if priority < last {
+			t.Fatalf("priorities extracted out of order: %d after %d", priority, last)
+		}
+		if priorities[key] != priority {
+			t.Fatalf("key %d: expected priority %d, got %d", key, priorities[key], priority)
+		}
+		last = priority
+	}
+}
@@ -0,0 +1,143 @@
+package ds_test
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/welschma/godsa/ds"
+)
+
+// simplePathCosts returns the costs of all simple paths from s to t in increasing
+// order, using the lightest edge between consecutive vertices.
+func simplePathCosts(g *ds.Graph, s, t int) []int {
+	weights := edgeWeights(g)
+	onPath := make([]bool, g.V())
+	costs := []int{}
+
+	var search func(x, cost int)
+	search = func(x, cost int) {
+		if x == t {
+			costs = append(costs, cost)
+			return
+		}
+
+		onPath[x] = true
+
+		for y := 0; y < g.V(); y++ {
+			if w, ok := weights[[2]int{x, y}]; ok && !onPath[y] {
+				search(y, cost+w)
+			}
+		}
+
+		onPath[x] = false
+	}
+
+	search(s, 0)
+	sort.Ints(costs)
+
+	return costs
+}
+
+func TestYen(t *testing.T) {
+	// the example of Yen's algorithm from C = 0 to H = 5
+	g := newTestGraph(6, true, [][3]int{
+		{0, 1, 3}, {0, 2, 2}, {1, 3, 4}, {2, 1, 1}, {2, 3, 2},
+		{2, 4, 3}, {3, 4, 2}, {3, 5, 1}, {4, 5, 2},
+	})
+
+	paths, err := ds.Yen(g, 0, 5, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []ds.Path{
+		{Vertices: []int{0, 2, 3, 5}, Cost: 5},
+		{Vertices: []int{0, 2, 4, 5}, Cost: 7},
+		{Vertices: []int{0, 1, 3, 5}, Cost: 8},
+		{Vertices: []int{0, 2, 1, 3, 5}, Cost: 8},
+	}
+
+	if !reflect.DeepEqual(paths, expected) {
+		t.Fatalf("expected %v, got %v", expected, paths)
+	}
+
+	if paths, _ := ds.Yen(g, 0, 5, 100); len(paths) != len(simplePathCosts(g, 0, 5)) {
+		t.Fatalf("expected all %d simple paths, got %d", len(simplePathCosts(g, 0, 5)), len(paths))
+	}
+
+	if paths, _ := ds.Yen(g, 5, 0, 3); len(paths) != 0 {
+		t.Fatalf("expected no paths, got %v", paths)
+	}
+
+	if paths, _ := ds.Yen(g, 2, 2, 3); !reflect.DeepEqual(paths, []ds.Path{{Vertices: []int{2}, Cost: 0}}) {
+		t.Fatalf("expected the empty path, got %v", paths)
+	}
+
+	if paths, _ := ds.Yen(g, 0, 5, 0); len(paths) != 0 {
+		t.Fatalf("expected no paths, got %v", paths)
+	}
+
+	if _, err := ds.Yen(g, 0, 6, 1); err == nil {
+		t.Fatal("expected an error for an invalid target")
+	}
+
+	if _, err := ds.Yen(g, 0, 5, -1); err == nil {
+		t.Fatal("expected an error for a negative number of paths")
+	}
+
+	if _, err := ds.Yen(newTestGraph(2, true, [][3]int{{0, 1, -1}}), 0, 1, 1); err == nil {
+		t.Fatal("expected an error for a negative weight")
+	}
+}
+
+func TestYenRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(25))
+
+	for i := 0; i < 200; i++ {
+		g := newRandomGraph(r, 2+r.Intn(7), r.Intn(20), r.Intn(2) == 0, 0, 10)
+		s, target, k := r.Intn(g.V()), r.Intn(g.V()), 1+r.Intn(10)
+		weights := edgeWeights(g)
+
+		paths, err := ds.Yen(g, s, target, k)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := simplePathCosts(g, s, target)
+		if len(expected) > k {
+			expected = expected[:k]
+		}
+
+		costs := []int{}
+		seen := map[string]bool{}
+
+		for _, p := range paths {
+			costs = append(costs, p.Cost)
+
+			if key := fmt.Sprint(p.Vertices); seen[key] {
+				t.Fatalf("graph %d: path %v is reported twice", i, p.Vertices)
+			} else {
+				seen[key] = true
+			}
+
+			visited := map[int]bool{}
+			for _, v := range p.Vertices {
+				if visited[v] {
+					t.Fatalf("graph %d: path %v is not simple", i, p.Vertices)
+				}
+				visited[v] = true
+			}
+
+			if p.Vertices[0] != s || p.Vertices[len(p.Vertices)-1] != target || pathWeight(weights, p.Vertices) != p.Cost {
+				t.Fatalf("graph %d: path %v does not lead from %d to %d with cost %d", i, p.Vertices, s, target, p.Cost)
+			}
+		}
+
+		if !reflect.DeepEqual(costs, expected) {
+			t.Fatalf("graph %d: expected costs %v, got %v", i, expected, costs)
+		}
+	}
+}
@@ -0,0 +1,307 @@
+package ds_test
+
+import (
+	"bytes"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/welschma/godsa/ds"
+)
+
+func TestLabeledGraph(t *testing.T) {
+	g := ds.NewLabeledGraph[string, float64](false)
+
+	g.AddEdge("berlin", "hamburg", 289.5)
+	g.AddEdge("berlin", "munich", 584.1)
+	g.AddVertex("bonn")
+	g.AddEdge("munich", "munich", 0)
+
+	if g.V() != 4 || g.E() != 6 || g.Directed() {
+		t.Fatalf("expected 4 vertices and 6 adjacency entries, got %d and %d", g.V(), g.E())
+	}
+
+	if i, ok := g.Index("munich"); !ok || i != 2 || g.AddVertex("munich") != 2 {
+		t.Fatalf("expected munich to be vertex 2, got %d", i)
+	}
+
+	if label, err := g.Label(3); err != nil || label != "bonn" {
+		t.Fatalf("expected bonn, got %q", label)
+	}
+
+	if _, err := g.Label(4); err == nil {
+		t.Fatal("expected an error for an invalid vertex")
+	}
+
+	if _, ok := g.Index("paris"); ok || g.HasVertex("paris") || g.Neighbors("paris") != nil {
+		t.Fatal("expected paris not to be a vertex")
+	}
+
+	if !reflect.DeepEqual(g.Labels(), []string{"berlin", "hamburg", "munich", "bonn"}) {
+		t.Fatalf("unexpected labels %v", g.Labels())
+	}
+
+	expected := []ds.LabeledEdge[string, float64]{
+		{From: "berlin", To: "munich", Weight: 584.1},
+		{From: "berlin", To: "hamburg", Weight: 289.5},
+		{From: "munich", To: "munich", Weight: 0},
+	}
+	if !reflect.DeepEqual(g.Edges(), expected) {
+		t.Fatalf("expected edges %v, got %v", expected, g.Edges())
+	}
+
+	neighbors := []ds.LabeledEdge[string, float64]{
+		{From: "munich", To: "munich", Weight: 0},
+		{From: "munich", To: "munich", Weight: 0},
+		{From: "munich", To: "berlin", Weight: 584.1},
+	}
+	if !reflect.DeepEqual(g.Neighbors("munich"), neighbors) {
+		t.Fatalf("expected neighbors %v, got %v", neighbors, g.Neighbors("munich"))
+	}
+}
+
+func TestLabeledGraphToGraph(t *testing.T) {
+	type task struct {
+		project string
+		id      int
+	}
+
+	g := ds.NewLabeledGraph[task, int](true)
+	g.AddEdge(task{"a", 1}, task{"a", 2}, 3)
+	g.AddEdge(task{"a", 2}, task{"b", 1}, 1)
+	g.AddEdge(task{"a", 1}, task{"b", 1}, 5)
+
+	graph, err := g.ToGraph()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	order, err := ds.TopologicalSort(graph)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	labels := []task{}
+	for _, i := range order {
+		label, _ := g.Label(i)
+		labels = append(labels, label)
+	}
+
+	if !reflect.DeepEqual(labels, []task{{"a", 1}, {"a", 2}, {"b", 1}}) {
+		t.Fatalf("unexpected order %v", labels)
+	}
+
+	var expected, got bytes.Buffer
+	newTestGraph(3, true, [][3]int{{0, 1, 3}, {1, 2, 1}, {0, 2, 5}}).Write(&expected)
+	graph.Write(&got)
+
+	if got.String() != expected.String() {
+		t.Fatalf("expected\n%s\ngot\n%s", expected.String(), got.String())
+	}
+}
+
+func TestLabeledGraphToGraphWeights(t *testing.T) {
+	// truncating the weights to 0, 0 and 0 would make any two edges a spanning tree
+	g := ds.NewLabeledGraph[string, float64](false)
+	g.AddEdge("a", "b", 0.5)
+	g.AddEdge("b", "c", 0.4)
+	g.AddEdge("a", "c", 0.95)
+
+	if _, err := g.ToGraph(); err == nil {
+		t.Fatal("expected an error for fractional weights")
+	}
+
+	sp, err := ds.LabeledDijkstra(g, "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if d, _ := sp.DistTo("c"); d != 0.9 {
+		t.Fatalf("expected distance 0.9 to c, got %v", d)
+	}
+
+	// integral float weights convert exactly
+	g = ds.NewLabeledGraph[string, float64](false)
+	g.AddEdge("a", "b", 5)
+	g.AddEdge("b", "c", 4)
+	g.AddEdge("a", "c", 10)
+
+	graph, err := g.ToGraph()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	forest, err := ds.Kruskal(graph)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if forest.Weight() != 9 {
+		t.Fatalf("expected a spanning tree of weight 9, got %d", forest.Weight())
+	}
+
+	big := ds.NewLabeledGraph[string, uint64](true)
+	big.AddEdge("a", "b", 1<<63)
+
+	if _, err := big.ToGraph(); err == nil {
+		t.Fatal("expected an error for a weight that does not fit into an int")
+	}
+
+	huge := ds.NewLabeledGraph[string, float64](true)
+	huge.AddEdge("a", "b", 1e30)
+
+	if _, err := huge.ToGraph(); err == nil {
+		t.Fatal("expected an error for a weight that does not fit into an int")
+	}
+}
+
+func TestLabeledGraphView(t *testing.T) {
+	g := ds.NewLabeledGraph[string, float64](false)
+	g.AddEdge("a", "b", 0.5)
+	g.AddEdge("b", "c", 0.4)
+	g.AddEdge("a", "c", 0.95)
+	g.AddEdge("c", "c", 0.1)
+
+	cents := g.View(func(w float64) int { return int(math.Round(100 * w)) })
+
+	if cents.V() != 3 || cents.Directed() {
+		t.Fatalf("expected an undirected view with 3 vertices, got %d", cents.V())
+	}
+
+	forest, err := ds.Kruskal(cents)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a, _ := g.Index("a")
+	b, _ := g.Index("b")
+	c, _ := g.Index("c")
+	expected := []ds.Edge{{From: b, To: c, Weight: 40}, {From: a, To: b, Weight: 50}}
+
+	if forest.Weight() != 90 || !reflect.DeepEqual(forest.Edges(), expected) {
+		t.Fatalf("expected the tree %v of weight 90, got %v of weight %d", expected, forest.Edges(), forest.Weight())
+	}
+
+	sp, err := ds.Dijkstra(cents, a)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sp.DistTo(c) != 90 {
+		t.Fatalf("expected distance 90 to c, got %d", sp.DistTo(c))
+	}
+
+	// the view follows changes of the graph
+	g.AddEdge("c", "d", 1.25)
+
+	if cents.V() != 4 {
+		t.Fatalf("expected 4 vertices, got %d", cents.V())
+	}
+
+	if sp, _ := ds.Dijkstra(cents, a); sp.DistTo(3) != 215 {
+		t.Fatalf("expected distance 215 to d, got %d", sp.DistTo(3))
+	}
+}
+
+func TestLabeledDijkstra(t *testing.T) {
+	g := ds.NewLabeledGraph[string, float64](true)
+	g.AddEdge("s", "a", 1.5)
+	g.AddEdge("s", "b", 4)
+	g.AddEdge("a", "b", 2)
+	g.AddEdge("b", "t", 0.25)
+	g.AddVertex("unreachable")
+
+	sp, err := ds.LabeledDijkstra(g, "s")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if d, ok := sp.DistTo("t"); !ok || d != 3.75 || sp.Source() != "s" {
+		t.Fatalf("expected distance 3.75, got %v", d)
+	}
+
+	if path := sp.PathTo("t"); !reflect.DeepEqual(path, []string{"s", "a", "b", "t"}) {
+		t.Fatalf("unexpected path %v", path)
+	}
+
+	if sp.HasPathTo("unreachable") || sp.HasPathTo("missing") || sp.PathTo("unreachable") != nil {
+		t.Fatal("expected no path to unreachable vertices")
+	}
+
+	if _, err := ds.LabeledDijkstra(g, "missing"); err == nil {
+		t.Fatal("expected an error for a missing source")
+	}
+
+	g.AddEdge("t", "s", -1)
+	if _, err := ds.LabeledDijkstra(g, "s"); err == nil {
+		t.Fatal("expected an error for a negative weight")
+	}
+}
+
+func TestLabeledDijkstraInvalidWeights(t *testing.T) {
+	g := ds.NewLabeledGraph[string, float64](true)
+	g.AddEdge("a", "b", math.NaN())
+	g.AddEdge("b", "a", 1)
+
+	if _, err := ds.LabeledDijkstra(g, "a"); err == nil {
+		t.Fatal("expected an error for a NaN weight")
+	}
+
+	// the distance of c overflows int8, the distance of d just fits
+	overflow := ds.NewLabeledGraph[string, int8](true)
+	overflow.AddEdge("a", "b", 100)
+	overflow.AddEdge("b", "c", 100)
+	overflow.AddEdge("b", "d", 27)
+
+	sp, err := ds.LabeledDijkstra(overflow, "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sp.HasPathTo("c") || sp.PathTo("c") != nil {
+		t.Fatal("expected no path to c, its distance overflows")
+	}
+
+	if d, ok := sp.DistTo("d"); !ok || d != 127 {
+		t.Fatalf("expected distance 127 to d, got %v", d)
+	}
+}
+
+func TestLabeledDijkstraMatchesDijkstra(t *testing.T) {
+	r := rand.New(rand.NewSource(19))
+
+	for i := 0; i < 100; i++ {
+		g := ds.NewLabeledGraph[int, int](r.Intn(2) == 0)
+
+		// labels are spread out and added in random order
+		for j := r.Intn(40); j > 0; j-- {
+			g.AddEdge(100*r.Intn(10), 100*r.Intn(10), r.Intn(20))
+		}
+
+		if g.V() == 0 {
+			continue
+		}
+
+		source, _ := g.Label(0)
+		sp, err := ds.LabeledDijkstra(g, source)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		graph, err := g.ToGraph()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected, _ := ds.Dijkstra(graph, 0)
+
+		for v, label := range g.Labels() {
+			d, ok := sp.DistTo(label)
+
+			if ok != expected.HasPathTo(v) || (ok && d != expected.DistTo(v)) {
+				t.Fatalf("graph %d: expected distance %d to %d, got %d", i, expected.DistTo(v), label, d)
+			}
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package ds_test
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/welschma/godsa/ds"
+)
+
+// checkMatching fails the test if a matched pair is not an edge of the graph or the
+// mates are inconsistent.
+func checkMatching(t *testing.T, g *ds.Graph, m *ds.Matching) {
+	t.Helper()
+
+	weights := edgeWeights(g)
+	matched := 0
+
+	for _, p := range m.Pairs() {
+		if _, ok := weights[p]; !ok {
+			t.Fatalf("matched pair %v is not an edge", p)
+		}
+
+		if m.Mate(p[0]) != p[1] || m.Mate(p[1]) != p[0] {
+			t.Fatalf("inconsistent mates for pair %v", p)
+		}
+	}
+
+	for v := 0; v < g.V(); v++ {
+		if m.IsMatched(v) {
+			matched++
+		}
+	}
+
+	if len(m.Pairs()) != m.Size() || matched != 2*m.Size() {
+		t.Fatalf("matching of size %d has %d pairs and %d matched vertices", m.Size(), len(m.Pairs()), matched)
+	}
+}
+
+// maxMatchingSize returns the size of a maximum matching of a bipartite graph,
+// computed as a maximum flow from the left side to the right side.
+func maxMatchingSize(g *ds.Graph) int {
+	b, _ := ds.Bipartite(g)
+	s, tt := g.V(), g.V()+1
+	network := ds.NewGraph(g.V()+2, true)
+
+	for _, e := range g.Edges() {
+		if b.Color(e.From) == 0 {
+			network.AddEdge(e.From, e.To, 1)
+		} else {
+			network.AddEdge(e.To, e.From, 1)
+		}
+	}
+
+	for v := 0; v < g.V(); v++ {
+		if b.Color(v) == 0 {
+			network.AddEdge(s, v, 1)
+		} else {
+			network.AddEdge(v, tt, 1)
+		}
+	}
+
+	mf, _ := ds.Dinic(network, s, tt)
+
+	return mf.Value()
+}
+
+func TestHopcroftKarp(t *testing.T) {
+	// reviewers 0-2 and changes 3-6, reviewer 0 can review everything
+	g := newTestGraph(7, false, [][3]int{{0, 3, 1}, {0, 4, 1}, {0, 5, 1}, {0, 6, 1}, {1, 3, 1}, {2, 3, 1}, {2, 4, 1}})
+
+	m, err := ds.HopcroftKarp(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if m.Size() != 3 || m.IsPerfect() {
+		t.Fatalf("expected an imperfect matching of size 3, got %v", m.Pairs())
+	}
+
+	if m.Mate(1) != 3 || m.Mate(2) != 4 {
+		t.Fatalf("unexpected matching %v", m.Pairs())
+	}
+
+	checkMatching(t, g, m)
+
+	if m.Mate(-1) != -1 || m.Mate(7) != -1 {
+		t.Fatal("expected -1 for invalid vertices")
+	}
+}
+
+func TestHopcroftKarpPerfect(t *testing.T) {
+	// the path 0 - 1 - 2 - 3 - 4 - 5 needs an augmenting path through all vertices
+	// once 1 - 2 and 3 - 4 are matched
+	g := newTestGraph(6, false, [][3]int{{1, 2, 1}, {3, 4, 1}, {0, 1, 1}, {2, 3, 1}, {4, 5, 1}})
+
+	m, err := ds.HopcroftKarp(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !m.IsPerfect() || !reflect.DeepEqual(m.Pairs(), [][2]int{{0, 1}, {2, 3}, {4, 5}}) {
+		t.Fatalf("expected a perfect matching, got %v", m.Pairs())
+	}
+}
+
+func TestHopcroftKarpErrors(t *testing.T) {
+	if _, err := ds.HopcroftKarp(ds.NewGraph(2, true)); err == nil {
+		t.Fatal("expected an error for a directed graph")
+	}
+
+	if _, err := ds.HopcroftKarp(newTestGraph(3, false, [][3]int{{0, 1, 1}, {1, 2, 1}, {2, 0, 1}})); err == nil {
+		t.Fatal("expected an error for a graph that is not bipartite")
+	}
+}
+
+func TestHopcroftKarpMatchesMaxFlow(t *testing.T) {
+	r := rand.New(rand.NewSource(14))
+
+	for i := 0; i < 300; i++ {
+		left, right := 1+r.Intn(10), 1+r.Intn(10)
+		g := ds.NewGraph(left+right, false)
+
+		for j := r.Intn(3 * (left + right)); j > 0; j-- {
+			g.AddEdge(r.Intn(left), left+r.Intn(right), 1)
+		}
+
+		m, err := ds.HopcroftKarp(g)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		checkMatching(t, g, m)
+
+		if expected := maxMatchingSize(g); m.Size() != expected {
+			t.Fatalf("graph %d: expected a matching of size %d, got %d", i, expected, m.Size())
+		}
+	}
+}
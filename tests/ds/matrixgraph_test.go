@@ -0,0 +1,142 @@
+package ds_test
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/welschma/godsa/ds"
+)
+
+func TestMatrixGraph(t *testing.T) {
+	for _, directed := range []bool{true, false} {
+		m, err := ds.NewMatrixGraph(3, directed)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, e := range [][3]int{{0, 1, 5}, {1, 2, 3}, {2, 2, 1}, {0, 1, 7}} {
+			if err := m.AddEdge(e[0], e[1], e[2]); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		// the second edge from 0 to 1 replaces the first one
+		expected := 3
+		if !directed {
+			expected = 6
+		}
+
+		if m.V() != 3 || m.E() != expected || m.Directed() != directed {
+			t.Fatalf("directed %v: expected %d edges, got %d", directed, expected, m.E())
+		}
+
+		if w, err := m.Weight(0, 1); err != nil || w != 7 {
+			t.Fatalf("expected weight 7, got %d", w)
+		}
+
+		if m.HasEdge(1, 0) != !directed || m.HasEdge(0, 2) || m.HasEdge(0, 3) {
+			t.Fatalf("directed %v: unexpected result of HasEdge", directed)
+		}
+
+		if d, err := m.Degree(2); err != nil || (directed && d != 1) || (!directed && d != 3) {
+			t.Fatalf("directed %v: unexpected degree %d", directed, d)
+		}
+
+		if err := m.RemoveEdge(1, 2); err != nil || m.HasEdge(1, 2) || m.HasEdge(2, 1) || m.E() != expected*2/3 {
+			t.Fatalf("directed %v: expected the edge 1 - 2 to be removed, got %v", directed, err)
+		}
+
+		for _, e := range [][2]int{{1, 2}, {0, 3}, {-1, 0}} {
+			if err := m.RemoveEdge(e[0], e[1]); err == nil {
+				t.Fatalf("expected an error for edge %v", e)
+			}
+		}
+
+		if err := m.AddEdge(3, 0, 1); err == nil {
+			t.Fatal("expected an error for an invalid vertex")
+		}
+
+		if _, err := m.Weight(1, 0); directed && err == nil {
+			t.Fatal("expected an error for a missing edge")
+		}
+
+		if _, err := m.Degree(3); err == nil {
+			t.Fatal("expected an error for an invalid vertex")
+		}
+	}
+}
+
+func TestNewMatrixGraphErrors(t *testing.T) {
+	for _, v := range []int{-1, math.MaxInt, math.MaxInt / 2} {
+		if _, err := ds.NewMatrixGraph(v, true); err == nil {
+			t.Fatalf("expected an error for %d vertices", v)
+		}
+	}
+
+	if m, err := ds.NewMatrixGraph(0, true); err != nil || m.V() != 0 {
+		t.Fatalf("expected an empty graph, got %v", err)
+	}
+}
+
+func TestMatrixGraphConversion(t *testing.T) {
+	g := newTestGraph(3, false, [][3]int{{0, 2, 4}, {0, 1, 5}, {2, 2, 1}, {1, 0, 2}})
+	m, err := ds.NewMatrixGraphFromGraph(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if w, _ := m.Weight(1, 0); w != 2 || m.E() != 6 {
+		t.Fatalf("expected the lighter parallel edge and 6 entries, got weight %d and %d entries", w, m.E())
+	}
+
+	// adjacency lists are sorted by target, the self-loop appears twice
+	expected := [][3]int{{0, 1, 2}, {0, 2, 4}, {1, 0, 2}, {2, 0, 4}, {2, 2, 1}, {2, 2, 1}}
+	if edges := graphEdges(m.ToGraph()); !reflect.DeepEqual(edges, expected) || m.ToGraph().E() != 6 {
+		t.Fatalf("expected %v, got %v", expected, edges)
+	}
+
+	edges := []ds.Edge{}
+	for it := m.OutEdges(2); it.HasNext(); {
+		e, _ := it.GetNext()
+		edges = append(edges, e)
+	}
+
+	if !reflect.DeepEqual(edges, []ds.Edge{{From: 2, To: 0, Weight: 4}, {From: 2, To: 2, Weight: 1}, {From: 2, To: 2, Weight: 1}}) {
+		t.Fatalf("unexpected edges %v", edges)
+	}
+}
+
+func TestMatrixGraphAlgorithms(t *testing.T) {
+	r := rand.New(rand.NewSource(22))
+
+	for i := 0; i < 100; i++ {
+		m, _ := ds.NewMatrixGraph(1+r.Intn(15), r.Intn(2) == 0)
+
+		for j := r.Intn(60); j > 0; j-- {
+			m.AddEdge(r.Intn(m.V()), r.Intn(m.V()), r.Intn(10))
+		}
+
+		// ToGraph keeps the adjacency order, so all results must be identical
+		g := m.ToGraph()
+
+		if !reflect.DeepEqual(ds.FloydWarshall(m), ds.FloydWarshall(g)) {
+			t.Fatalf("graph %d: Floyd-Warshall results differ", i)
+		}
+
+		bfsMatrix, _ := ds.BreadthFirstSearch(m, 0, nil)
+		bfsGraph, _ := ds.BreadthFirstSearch(g, 0, nil)
+		spMatrix, _ := ds.Dijkstra(m, 0)
+		spGraph, _ := ds.Dijkstra(g, 0)
+
+		if !reflect.DeepEqual(bfsMatrix, bfsGraph) || !reflect.DeepEqual(spMatrix, spGraph) ||
+			!reflect.DeepEqual(ds.DepthFirstSearchAll(m, nil), ds.DepthFirstSearchAll(g, nil)) {
+			t.Fatalf("graph %d: results differ between MatrixGraph and Graph", i)
+		}
+
+		if copied, _ := ds.NewMatrixGraphFromGraph(g); !reflect.DeepEqual(graphEdges(copied.ToGraph()), graphEdges(g)) {
+			t.Fatalf("graph %d: conversion does not round trip", i)
+		}
+	}
+}
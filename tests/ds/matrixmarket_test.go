@@ -0,0 +1,90 @@
+package ds_test
+
+import (
+	"bytes"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/welschma/godsa/ds"
+)
+
+func TestMatrixMarketRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(18))
+
+	for i := 0; i < 100; i++ {
+		directed := r.Intn(2) == 0
+		g := newRandomGraph(r, 1+r.Intn(10), r.Intn(30), directed, -5, 5)
+
+		var buffer bytes.Buffer
+		if err := g.WriteMatrixMarket(&buffer); err != nil {
+			t.Fatal(err)
+		}
+
+		read, err := ds.ReadMatrixMarket(&buffer)
+		if err != nil {
+			t.Fatalf("graph %d: %v", i, err)
+		}
+
+		if read.V() != g.V() || read.E() != g.E() || read.Directed() != directed {
+			t.Fatalf("graph %d: expected %d vertices and %d edges, got %d and %d", i, g.V(), g.E(), read.V(), read.E())
+		}
+
+		if !reflect.DeepEqual(sortedEdges(read), sortedEdges(g)) {
+			t.Fatalf("graph %d: expected %v, got %v", i, sortedEdges(g), sortedEdges(read))
+		}
+	}
+}
+
+func TestReadMatrixMarket(t *testing.T) {
+	input := "%%MatrixMarket matrix coordinate real general\n% weights\n3 3 2\n1 2 2.0\n3 1 -4e0\n"
+
+	g, err := ds.ReadMatrixMarket(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []ds.Edge{{From: 0, To: 1, Weight: 2}, {From: 2, To: 0, Weight: -4}}
+	if !g.Directed() || !reflect.DeepEqual(sortedEdges(g), expected) {
+		t.Fatalf("expected %v, got %v", expected, sortedEdges(g))
+	}
+
+	input = "%%MatrixMarket Matrix Coordinate Pattern Symmetric\n2 2 1\n2 1\n"
+
+	g, err = ds.ReadMatrixMarket(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected = []ds.Edge{{From: 0, To: 1, Weight: 1}}
+	if g.Directed() || !reflect.DeepEqual(sortedEdges(g), expected) {
+		t.Fatalf("expected %v, got %v", expected, sortedEdges(g))
+	}
+}
+
+func TestReadMatrixMarketErrors(t *testing.T) {
+	header := "%%MatrixMarket matrix coordinate integer general\n"
+
+	for _, tc := range []struct{ input, line string }{
+		{"", "line 1:"},
+		{"2 2 1\n", "line 1:"},
+		{"%%MatrixMarket matrix array integer general\n", "line 1:"},
+		{"%%MatrixMarket matrix coordinate complex general\n", "line 1:"},
+		{"%%MatrixMarket matrix coordinate integer hermitian\n", "line 1:"},
+		{header, "line 2:"},
+		{header + "2 3 1\n", "line 2:"},
+		{header + "999999999999999999 999999999999999999 0\n", "line 2:"},
+		{header + "2 2 1\n1 2\n", "line 3:"},
+		{header + "2 2 1\n1 3 1\n", "line 3:"},
+		{header + "2 2 1\n1 2 1.5\n", "line 3:"},
+		{header + "2 2 2\n1 2 1\n", "line 4:"},
+		{"%%MatrixMarket matrix coordinate real general\n2 2 1\n1 2 1.5\n", "line 3:"},
+	} {
+		_, err := ds.ReadMatrixMarket(strings.NewReader(tc.input))
+
+		if err == nil || !strings.HasPrefix(err.Error(), tc.line) {
+			t.Fatalf("input %q: expected an error starting with %q, got %v", tc.input, tc.line, err)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package ds_test
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/welschma/godsa/ds"
+)
+
+var maxFlowAlgorithms = map[string]func(ds.GraphView, int, int) (*ds.MaxFlow, error){
+	"EdmondsKarp": ds.EdmondsKarp,
+	"Dinic":       ds.Dinic,
+}
+
+// checkFlow fails the test if the flow violates capacity or conservation constraints
+// or does not match the capacity of its minimum cut.
+func checkFlow(t *testing.T, g *ds.Graph, s, tt int, mf *ds.MaxFlow) {
+	t.Helper()
+
+	balance := make([]int, g.V())
+
+	for _, e := range mf.Edges() {
+		if e.Flow < 0 || e.Flow > e.Capacity {
+			t.Fatalf("edge %v violates its capacity", e)
+		}
+		balance[e.From] -= e.Flow
+		balance[e.To] += e.Flow
+	}
+
+	for v, b := range balance {
+		switch {
+		case v == s && b != -mf.Value(), v == tt && b != mf.Value():
+			t.Fatalf("vertex %d: expected balance %d, got %d", v, mf.Value(), b)
+		case v != s && v != tt && b != 0:
+			t.Fatalf("vertex %d violates flow conservation", v)
+		}
+	}
+
+	if !mf.InCut(s) || mf.InCut(tt) {
+		t.Fatal("minimum cut must separate source and sink")
+	}
+
+	capacity := 0
+	for _, e := range mf.CutEdges() {
+		capacity += e.Capacity
+	}
+
+	if capacity != mf.Value() {
+		t.Fatalf("cut capacity %d does not match flow value %d", capacity, mf.Value())
+	}
+}
+
+func TestMaxFlow(t *testing.T) {
+	// CLRS figure 26.1
+	g := newTestGraph(6, true, [][3]int{
+		{0, 1, 16}, {0, 2, 13}, {2, 1, 4}, {1, 3, 12}, {3, 2, 9}, {2, 4, 14}, {4, 3, 7}, {3, 5, 20}, {4, 5, 4},
+	})
+
+	for name, algorithm := range maxFlowAlgorithms {
+		mf, err := algorithm(g, 0, 5)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		if mf.Value() != 23 {
+			t.Errorf("%s: expected flow 23, got %d", name, mf.Value())
+		}
+
+		if got := mf.MinCut(); !reflect.DeepEqual(got, []int{0, 1, 2, 4}) {
+			t.Errorf("%s: min cut: want [0 1 2 4], got %v", name, got)
+		}
+
+		if mf.Flow(3, 5) != 19 || mf.Flow(4, 5) != 4 {
+			t.Errorf("%s: unexpected flow into the sink", name)
+		}
+
+		checkFlow(t, g, 0, 5, mf)
+	}
+}
+
+func TestMaxFlowUndirected(t *testing.T) {
+	g := newTestGraph(4, false, [][3]int{{0, 1, 3}, {1, 3, 2}, {0, 2, 2}, {2, 3, 3}, {1, 2, 5}})
+
+	for name, algorithm := range maxFlowAlgorithms {
+		mf, _ := algorithm(g, 0, 3)
+
+		if mf.Value() != 5 {
+			t.Errorf("%s: expected flow 5, got %d", name, mf.Value())
+		}
+
+		checkFlow(t, g, 0, 3, mf)
+	}
+}
+
+func TestMaxFlowErrors(t *testing.T) {
+	g := newTestGraph(3, true, [][3]int{{0, 1, 1}, {1, 2, -1}})
+
+	for name, algorithm := range maxFlowAlgorithms {
+		if _, err := algorithm(g, 0, 2); err == nil {
+			t.Errorf("%s: expected error for negative capacity", name)
+		}
+
+		if _, err := algorithm(g, 1, 1); err == nil {
+			t.Errorf("%s: expected error for equal source and sink", name)
+		}
+
+		if _, err := algorithm(g, 0, 3); err == nil {
+			t.Errorf("%s: expected error for sink out of bounds", name)
+		}
+	}
+}
+
+func TestMaxFlowRandomized(t *testing.T) {
+	r := rand.New(rand.NewSource(16))
+
+	for i := 0; i < 30; i++ {
+		g := newRandomGraph(r, 20, 80, i%3 != 0, 0, 25)
+		edmondsKarp, _ := ds.EdmondsKarp(g, 0, 19)
+		dinic, _ := ds.Dinic(g, 0, 19)
+
+		if edmondsKarp.Value() != dinic.Value() {
+			t.Fatalf("flow values differ: edmonds-karp %d, dinic %d", edmondsKarp.Value(), dinic.Value())
+		}
+
+		checkFlow(t, g, 0, 19, edmondsKarp)
+		checkFlow(t, g, 0, 19, dinic)
+	}
+}
@@ -0,0 +1,221 @@
+package ds_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/welschma/godsa/ds"
+)
+
+// checkMinCostFlow fails the test if the network carries an invalid flow of the given
+// value and cost, or if its residual network has a cycle of negative cost, i.e. the
+// flow is not the cheapest one of its value.
+func checkMinCostFlow(t *testing.T, fn *ds.FlowNetwork, s, tt, value, cost int) {
+	t.Helper()
+
+	balance := make([]int, fn.V())
+	residual := ds.NewGraph(fn.V()+1, true)
+	total := 0
+
+	for _, e := range fn.Edges() {
+		if e.Flow < 0 || e.Flow > e.Capacity {
+			t.Fatalf("edge %v violates its capacity", e)
+		}
+
+		balance[e.From] -= e.Flow
+		balance[e.To] += e.Flow
+		total += e.Flow * e.Cost
+
+		if e.Flow < e.Capacity {
+			residual.AddEdge(e.From, e.To, e.Cost)
+		}
+		if e.Flow > 0 {
+			residual.AddEdge(e.To, e.From, -e.Cost)
+		}
+	}
+
+	for v, b := range balance {
+		switch {
+		case v == s && b != -value, v == tt && b != value:
+			t.Fatalf("vertex %d: expected balance %d, got %d", v, value, b)
+		case v != s && v != tt && b != 0:
+			t.Fatalf("vertex %d violates flow conservation", v)
+		}
+	}
+
+	if total != cost || fn.TotalCost() != cost {
+		t.Fatalf("expected cost %d, edges add up to %d, network reports %d", cost, total, fn.TotalCost())
+	}
+
+	for v := 0; v < fn.V(); v++ {
+		residual.AddEdge(fn.V(), v, 0)
+	}
+
+	sp, _ := ds.BellmanFord(residual, fn.V())
+	if sp.HasNegativeCycle() {
+		t.Fatalf("residual network has a negative cycle %v", sp.NegativeCycle())
+	}
+}
+
+func newTestFlowNetwork(v int, edges [][4]int) *ds.FlowNetwork {
+	fn := ds.NewFlowNetwork(v)
+
+	for _, e := range edges {
+		fn.AddEdge(e[0], e[1], e[2], e[3])
+	}
+
+	return fn
+}
+
+func TestMinCostFlow(t *testing.T) {
+	fn := newTestFlowNetwork(4, [][4]int{
+		{0, 1, 2, 1}, {0, 2, 1, 2}, {1, 2, 1, 1}, {1, 3, 1, 3}, {2, 3, 2, 1},
+	})
+
+	for _, tc := range []struct{ limit, flow, cost int }{
+		{-1, 3, 10}, {0, 0, 0}, {1, 1, 3}, {2, 2, 6}, {3, 3, 10}, {5, 3, 10},
+	} {
+		flow, cost, err := fn.MinCostFlow(0, 3, tc.limit)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if flow != tc.flow || cost != tc.cost {
+			t.Fatalf("limit %d: expected flow %d at cost %d, got %d at cost %d", tc.limit, tc.flow, tc.cost, flow, cost)
+		}
+
+		checkMinCostFlow(t, fn, 0, 3, flow, cost)
+	}
+
+	if e, _ := fn.Edge(3); e != (ds.FlowEdge{From: 1, To: 3, Capacity: 1, Cost: 3, Flow: 1}) {
+		t.Fatalf("unexpected edge %v", e)
+	}
+}
+
+func TestMinCostFlowNegativeCosts(t *testing.T) {
+	fn := newTestFlowNetwork(4, [][4]int{
+		{0, 1, 1, 5}, {0, 2, 1, 1}, {1, 3, 1, -4}, {2, 3, 1, 1}, {2, 1, 1, -3},
+	})
+
+	flow, cost, err := fn.MinCostMaxFlow(0, 3)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if flow != 2 || cost != 3 {
+		t.Fatalf("expected flow 2 at cost 3, got %d at cost %d", flow, cost)
+	}
+
+	checkMinCostFlow(t, fn, 0, 3, flow, cost)
+}
+
+func TestMinCostFlowErrors(t *testing.T) {
+	fn := newTestFlowNetwork(4, [][4]int{{0, 1, 1, 1}, {1, 2, 1, -2}, {2, 1, 1, 1}, {2, 3, 1, 0}})
+
+	if _, _, err := fn.MinCostMaxFlow(0, 3); err == nil {
+		t.Fatal("expected an error for a negative cycle")
+	}
+
+	for _, st := range [][2]int{{-1, 3}, {0, 4}, {2, 2}} {
+		if _, _, err := fn.MinCostMaxFlow(st[0], st[1]); err == nil {
+			t.Fatalf("expected an error for source %d and sink %d", st[0], st[1])
+		}
+	}
+
+	for _, e := range [][4]int{{-1, 0, 1, 0}, {0, 4, 1, 0}, {0, 1, -1, 0}} {
+		if _, err := fn.AddEdge(e[0], e[1], e[2], e[3]); err == nil {
+			t.Fatalf("expected an error for edge %v", e)
+		}
+	}
+
+	if _, err := fn.Edge(4); err == nil {
+		t.Fatal("expected an error for a missing edge")
+	}
+
+	if _, err := ds.NewFlowNetworkFromGraph(newTestGraph(2, true, [][3]int{{0, 1, -1}}), nil); err == nil {
+		t.Fatal("expected an error for a negative capacity")
+	}
+}
+
+func TestMinCostFlowOverflow(t *testing.T) {
+	for _, edges := range [][][4]int{
+		// the cost of the path 0 -> 1 -> 2 overflows
+		{{0, 1, 1, math.MaxInt - 1}, {1, 2, 1, 10}},
+		// every unit is cheap enough, but the total cost overflows
+		{{0, 1, 4, math.MaxInt / 2}, {1, 2, 4, 0}},
+	} {
+		fn := newTestFlowNetwork(3, edges)
+
+		if _, _, err := fn.MinCostMaxFlow(0, 2); err == nil {
+			t.Fatalf("edges %v: expected an error for an overflowing cost", edges)
+		}
+	}
+
+	fn := newTestFlowNetwork(3, [][4]int{{0, 1, 1, math.MaxInt - 11}, {1, 2, 1, 10}})
+
+	if flow, cost, err := fn.MinCostMaxFlow(0, 2); err != nil || flow != 1 || cost != math.MaxInt-1 {
+		t.Fatalf("expected flow 1 at cost %d, got %d at cost %d: %v", math.MaxInt-1, flow, cost, err)
+	}
+}
+
+func TestNewFlowNetworkFromGraph(t *testing.T) {
+	g := newTestGraph(4, false, [][3]int{{0, 1, 2}, {1, 3, 1}, {0, 2, 1}, {2, 3, 2}})
+	costOf := func(x, y, weight int) int { return x + y }
+
+	fn, err := ds.NewFlowNetworkFromGraph(g, costOf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if fn.V() != 4 || fn.E() != 8 {
+		t.Fatalf("expected 4 vertices and 8 edges, got %d and %d", fn.V(), fn.E())
+	}
+
+	flow, cost, err := fn.MinCostMaxFlow(0, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if flow != 2 || cost != 12 {
+		t.Fatalf("expected flow 2 at cost 12, got %d at cost %d", flow, cost)
+	}
+
+	checkMinCostFlow(t, fn, 0, 3, flow, cost)
+}
+
+func TestMinCostFlowMatchesMaxFlow(t *testing.T) {
+	r := rand.New(rand.NewSource(13))
+
+	for i := 0; i < 200; i++ {
+		v := 2 + r.Intn(10)
+		g := newRandomGraph(r, v, r.Intn(4*v), r.Intn(2) == 0, 0, 10)
+		costs := map[[2]int]int{}
+		cost := func(x, y, weight int) int {
+			if _, ok := costs[[2]int{x, y}]; !ok {
+				costs[[2]int{x, y}] = r.Intn(20)
+			}
+			return costs[[2]int{x, y}]
+		}
+
+		fn, err := ds.NewFlowNetworkFromGraph(g, cost)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		mf, _ := ds.Dinic(g, 0, v-1)
+		flow, c, err := fn.MinCostMaxFlow(0, v-1)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if flow != mf.Value() {
+			t.Fatalf("graph %d: expected flow %d, got %d", i, mf.Value(), flow)
+		}
+
+		checkMinCostFlow(t, fn, 0, v-1, flow, c)
+	}
+}
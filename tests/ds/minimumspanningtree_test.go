@@ -0,0 +1,87 @@
+package ds_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/welschma/godsa/ds"
+)
+
+var spanningForestAlgorithms = map[string]func(ds.GraphView) (*ds.SpanningForest, error){
+	"Kruskal":   ds.Kruskal,
+	"LazyPrim":  ds.LazyPrim,
+	"EagerPrim": ds.EagerPrim,
+}
+
+func TestMinimumSpanningTree(t *testing.T) {
+	// tinyEWG from Algorithms, 4th edition, with weights scaled by 100
+	g := newTestGraph(8, false, [][3]int{
+		{4, 5, 35}, {4, 7, 37}, {5, 7, 28}, {0, 7, 16}, {1, 5, 32}, {0, 4, 38}, {2, 3, 17}, {1, 7, 19},
+		{0, 2, 26}, {1, 2, 36}, {1, 3, 29}, {2, 7, 34}, {6, 2, 40}, {3, 6, 52}, {6, 0, 58}, {6, 4, 93},
+	})
+
+	for name, algorithm := range spanningForestAlgorithms {
+		forest, err := algorithm(g)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		if forest.Weight() != 181 {
+			t.Errorf("%s: expected weight 181, got %d", name, forest.Weight())
+		}
+
+		if len(forest.Edges()) != 7 || !forest.IsSpanningTree() {
+			t.Errorf("%s: expected a spanning tree with 7 edges, got %d edges", name, len(forest.Edges()))
+		}
+	}
+}
+
+func TestMinimumSpanningForest(t *testing.T) {
+	// two components and an isolated vertex, with a self-loop and a parallel edge
+	g := newTestGraph(6, false, [][3]int{{0, 1, 3}, {1, 2, 1}, {0, 2, 1}, {3, 4, 5}, {3, 4, 2}, {4, 4, -7}})
+
+	for name, algorithm := range spanningForestAlgorithms {
+		forest, _ := algorithm(g)
+
+		if forest.Components() != 3 || forest.IsSpanningTree() {
+			t.Errorf("%s: expected 3 components, got %d", name, forest.Components())
+		}
+
+		if forest.Weight() != 4 || len(forest.Edges()) != 3 {
+			t.Errorf("%s: expected weight 4 with 3 edges, got %d with %d edges",
+				name, forest.Weight(), len(forest.Edges()))
+		}
+	}
+}
+
+func TestMinimumSpanningTreeDirected(t *testing.T) {
+	g := newTestGraph(2, true, [][3]int{{0, 1, 1}})
+
+	for name, algorithm := range spanningForestAlgorithms {
+		if _, err := algorithm(g); err == nil {
+			t.Errorf("%s: expected error for directed graph", name)
+		}
+	}
+}
+
+func TestMinimumSpanningTreeRandomized(t *testing.T) {
+	r := rand.New(rand.NewSource(12))
+
+	for i := 0; i < 20; i++ {
+		g := newRandomGraph(r, 40, 100, false, -10, 50)
+		kruskal, _ := ds.Kruskal(g)
+
+		for name, algorithm := range spanningForestAlgorithms {
+			forest, _ := algorithm(g)
+
+			if forest.Weight() != kruskal.Weight() || forest.Components() != kruskal.Components() {
+				t.Fatalf("%s: expected weight %d with %d components, got %d with %d",
+					name, kruskal.Weight(), kruskal.Components(), forest.Weight(), forest.Components())
+			}
+
+			if len(forest.Edges()) != g.V()-forest.Components() {
+				t.Fatalf("%s: expected %d edges, got %d", name, g.V()-forest.Components(), len(forest.Edges()))
+			}
+		}
+	}
+}
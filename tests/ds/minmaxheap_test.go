@@ -0,0 +1,120 @@
+package ds_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/welschma/godsa/ds"
+)
+
+func TestMinMaxHeapEmpty(t *testing.T) {
+	h := ds.NewMinMaxHeap[int](0, compareInt)
+
+	if !h.IsEmpty() || h.Limit() != 0 {
+		t.Error("new heap should be empty and unbounded")
+	}
+
+	if _, err := h.PeekMin(); err == nil {
+		t.Error("expected error when peeking into empty heap")
+	}
+
+	if _, err := h.ExtractMax(); err == nil {
+		t.Error("expected error when extracting from empty heap")
+	}
+}
+
+func TestMinMaxHeapBothEnds(t *testing.T) {
+	r := rand.New(rand.NewSource(8))
+	h := ds.NewMinMaxHeap[int](1, compareInt)
+	want := []int{}
+
+	for i := 0; i < 300; i++ {
+		v := r.Intn(100)
+		h.Insert(v)
+		want = append(want, v)
+	}
+
+	sort.Ints(want)
+
+	for len(want) > 0 {
+		if min, _ := h.PeekMin(); min != want[0] {
+			t.Fatalf("peek min: expected %d, got %d", want[0], min)
+		}
+
+		if max, _ := h.PeekMax(); max != want[len(want)-1] {
+			t.Fatalf("peek max: expected %d, got %d", want[len(want)-1], max)
+		}
+
+		if r.Intn(2) == 0 {
+			got, _ := h.ExtractMin()
+			if got != want[0] {
+				t.Fatalf("extract min: expected %d, got %d", want[0], got)
+			}
+			want = want[1:]
+		} else {
+			got, _ := h.ExtractMax()
+			if got != want[len(want)-1] {
+				t.Fatalf("extract max: expected %d, got %d", want[len(want)-1], got)
+			}
+			want = want[:len(want)-1]
+		}
+
+		if h.Size() != len(want) {
+			t.Fatalf("expected size %d, got %d", len(want), h.Size())
+		}
+	}
+}
+
+func TestMinMaxHeapBounded(t *testing.T) {
+	values := rand.New(rand.NewSource(9)).Perm(100)
+
+	t.Run("evict min keeps largest", func(t *testing.T) {
+		h := ds.NewBoundedMinMaxHeap[int](10, ds.EvictMin, compareInt)
+
+		for i, v := range values {
+			_, evicted := h.Insert(v)
+			if evicted != (i >= 10) {
+				t.Fatalf("insert %d: unexpected eviction flag %v", i, evicted)
+			}
+		}
+
+		for want := 90; want < 100; want++ {
+			if got, _ := h.ExtractMin(); got != want {
+				t.Fatalf("extract min: expected %d, got %d", want, got)
+			}
+		}
+	})
+
+	t.Run("evict max keeps smallest", func(t *testing.T) {
+		h := ds.NewBoundedMinMaxHeap[int](10, ds.EvictMax, compareInt)
+
+		for _, v := range values {
+			h.Insert(v)
+		}
+
+		if h.Size() != 10 {
+			t.Fatalf("expected size 10, got %d", h.Size())
+		}
+
+		for want := 9; want >= 0; want-- {
+			if got, _ := h.ExtractMax(); got != want {
+				t.Fatalf("extract max: expected %d, got %d", want, got)
+			}
+		}
+	})
+
+	t.Run("rejected element is returned", func(t *testing.T) {
+		h := ds.NewBoundedMinMaxHeap[int](2, ds.EvictMin, compareInt)
+		h.Insert(5)
+		h.Insert(6)
+
+		if got, ok := h.Insert(1); !ok || got != 1 {
+			t.Errorf("expected 1 to be evicted, got %d (%v)", got, ok)
+		}
+
+		if got, ok := h.Insert(7); !ok || got != 5 {
+			t.Errorf("expected 5 to be evicted, got %d (%v)", got, ok)
+		}
+	})
+}
@@ -0,0 +1,206 @@
+package ds_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/welschma/godsa/ds"
+)
+
+var pathSearchAlgorithms = map[string]func(ds.GraphView, int, int) (*ds.PathSearch, error){
+	"AStar":                 func(g ds.GraphView, s, t int) (*ds.PathSearch, error) { return ds.AStar(g, s, t, nil) },
+	"BidirectionalDijkstra": ds.BidirectionalDijkstra,
+}
+
+// newGridGraph returns an undirected n x n grid with random weights from 1 to 9,
+// whose vertices are numbered row by row.
+func newGridGraph(r *rand.Rand, n int) *ds.Graph {
+	g := ds.NewGraph(n*n, false)
+
+	for x := 0; x < n*n; x++ {
+		if x%n+1 < n {
+			g.AddEdge(x, x+1, 1+r.Intn(9))
+		}
+
+		if x+n < n*n {
+			g.AddEdge(x, x+n, 1+r.Intn(9))
+		}
+	}
+
+	return g
+}
+
+// manhattan returns a heuristic estimating the distance to t on an n x n grid with
+// edge weights of at least 1.
+func manhattan(n, t int) func(v int) int {
+	return func(v int) int {
+		dr, dc := v/n-t/n, v%n-t%n
+
+		if dr < 0 {
+			dr = -dr
+		}
+
+		if dc < 0 {
+			dc = -dc
+		}
+
+		return dr + dc
+	}
+}
+
+// checkPathSearch verifies a path search result against the shortest path tree of
+// Dijkstra from the same source.
+func checkPathSearch(t *testing.T, name string, g *ds.Graph, ps *ds.PathSearch, sp *ds.ShortestPaths, target int) {
+	t.Helper()
+
+	if ps.Found() != sp.HasPathTo(target) || ps.Cost() != sp.DistTo(target) {
+		t.Fatalf("%s: expected cost %d from %d to %d, got %d", name, sp.DistTo(target), sp.Source(), target, ps.Cost())
+	}
+
+	if !ps.Found() {
+		if ps.Path() != nil {
+			t.Fatalf("%s: expected no path, got %v", name, ps.Path())
+		}
+		return
+	}
+
+	path := ps.Path()
+
+	if path[0] != sp.Source() || path[len(path)-1] != target || pathWeight(edgeWeights(g), path) != ps.Cost() {
+		t.Fatalf("%s: path %v does not lead from %d to %d with cost %d", name, path, sp.Source(), target, ps.Cost())
+	}
+}
+
+func TestPathSearch(t *testing.T) {
+	g := newTestGraph(7, true, [][3]int{
+		{0, 1, 7}, {0, 2, 9}, {0, 5, 14}, {1, 2, 10}, {1, 3, 15},
+		{2, 3, 11}, {2, 5, 2}, {3, 4, 6}, {5, 4, 9},
+	})
+	sp, _ := ds.Dijkstra(g, 0)
+
+	for name, algorithm := range pathSearchAlgorithms {
+		for target := 0; target < g.V(); target++ {
+			ps, err := algorithm(g, 0, target)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			checkPathSearch(t, name, g, ps, sp, target)
+		}
+
+		if ps, _ := algorithm(g, 3, 3); ps.Cost() != 0 || len(ps.Path()) != 1 {
+			t.Fatalf("%s: expected an empty path from 3 to itself, got %v", name, ps.Path())
+		}
+
+		if _, err := algorithm(g, 0, 7); err == nil {
+			t.Fatalf("%s: expected an error for an invalid target", name)
+		}
+
+		if _, err := algorithm(g, -1, 0); err == nil {
+			t.Fatalf("%s: expected an error for an invalid source", name)
+		}
+
+		negative := newTestGraph(3, true, [][3]int{{0, 1, 1}, {1, 2, -1}})
+
+		if _, err := algorithm(negative, 0, 2); err == nil || err.Error() != "edge 1 -> 2 has negative weight -1" {
+			t.Fatalf("%s: expected an error for a negative weight, got %v", name, err)
+		}
+	}
+}
+
+func TestPathSearchOverflow(t *testing.T) {
+	g := newTestGraph(4, true, [][3]int{{0, 1, math.MaxInt - 1}, {1, 2, 10}, {0, 3, math.MaxInt - 10}, {3, 2, 5}})
+
+	for name, algorithm := range pathSearchAlgorithms {
+		if ps, _ := algorithm(g, 0, 2); ps.Cost() != math.MaxInt-5 {
+			t.Fatalf("%s: expected cost %d, got %d", name, math.MaxInt-5, ps.Cost())
+		}
+
+		// the only path is longer than the largest int
+		overflow := newTestGraph(3, true, [][3]int{{0, 1, math.MaxInt - 1}, {1, 2, 10}})
+
+		if ps, _ := algorithm(overflow, 0, 2); ps.Found() || ps.Cost() != ds.INFINITE_DISTANCE {
+			t.Fatalf("%s: expected no path, got %v with cost %d", name, ps.Path(), ps.Cost())
+		}
+	}
+
+	huge := func(v int) int { return math.MaxInt - 1 }
+
+	if ps, _ := ds.AStar(g, 0, 3, huge); ps.Cost() != math.MaxInt-10 {
+		t.Fatalf("expected cost %d with a huge heuristic, got %d", math.MaxInt-10, ps.Cost())
+	}
+}
+
+func TestPathSearchRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(24))
+
+	for i := 0; i < 200; i++ {
+		g := newRandomGraph(r, 1+r.Intn(30), r.Intn(90), r.Intn(2) == 0, 0, 20)
+		s, target := r.Intn(g.V()), r.Intn(g.V())
+		sp, _ := ds.Dijkstra(g, s)
+
+		for name, algorithm := range pathSearchAlgorithms {
+			ps, err := algorithm(g, s, target)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			checkPathSearch(t, name, g, ps, sp, target)
+		}
+	}
+}
+
+func TestAStarHeuristics(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	n := 30
+	g := newGridGraph(r, n)
+
+	for i := 0; i < 20; i++ {
+		s, target := r.Intn(n*n), r.Intn(n*n)
+		sp, _ := ds.Dijkstra(g, s)
+		exact, _ := ds.Dijkstra(g, target)
+
+		plain, _ := ds.AStar(g, s, target, nil)
+		goal, _ := ds.AStar(g, s, target, manhattan(n, target))
+
+		// an admissible but inconsistent heuristic forces vertices to be reopened
+		noisy, _ := ds.AStar(g, s, target, func(v int) int { return r.Intn(exact.DistTo(v) + 1) })
+
+		checkPathSearch(t, "plain", g, plain, sp, target)
+		checkPathSearch(t, "manhattan", g, goal, sp, target)
+		checkPathSearch(t, "noisy", g, noisy, sp, target)
+
+		if goal.Expanded() > plain.Expanded() {
+			t.Fatalf("expected the manhattan heuristic to expand at most %d vertices, got %d", plain.Expanded(), goal.Expanded())
+		}
+
+		// the exact distance leads straight to the target, unless there are ties
+		perfect, _ := ds.AStar(g, s, target, exact.DistTo)
+		checkPathSearch(t, "exact", g, perfect, sp, target)
+
+		if perfect.Expanded() > goal.Expanded() {
+			t.Fatalf("expected the exact heuristic to expand at most %d vertices, got %d", goal.Expanded(), perfect.Expanded())
+		}
+	}
+}
+
+func TestBidirectionalDijkstraExpansions(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	n := 60
+	g := newGridGraph(r, n)
+
+	// opposite ends of the middle row
+	s, target := n*n/2, n*n/2+n-1
+
+	plain, _ := ds.AStar(g, s, target, nil)
+	bidirectional, _ := ds.BidirectionalDijkstra(g, s, target)
+
+	if plain.Cost() != bidirectional.Cost() {
+		t.Fatalf("expected cost %d, got %d", plain.Cost(), bidirectional.Cost())
+	}
+
+	if bidirectional.Expanded() >= plain.Expanded() {
+		t.Fatalf("expected fewer than %d expansions, got %d", plain.Expanded(), bidirectional.Expanded())
+	}
+}
@@ -0,0 +1,336 @@
+package ds_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/welschma/godsa/ds"
+)
+
+// minPriorityQueues returns a fresh instance of every min-ordered priority queue.
+func minPriorityQueues() map[string]ds.PriorityQueue[int] {
+	return map[string]ds.PriorityQueue[int]{
+		"Heap":          ds.NewMinHeap[int](0, compareInt),
+		"PairingHeap":   ds.NewPairingHeap[int](compareInt),
+		"FibonacciHeap": ds.NewFibonacciHeap[int](compareInt),
+	}
+}
+
+func TestPriorityQueueOrder(t *testing.T) {
+	for name, pq := range minPriorityQueues() {
+		t.Run(name, func(t *testing.T) {
+			r := rand.New(rand.NewSource(4))
+			want := []int{}
+
+			for i := 0; i < 500; i++ {
+				v := r.Intn(100)
+				pq.Insert(v)
+				want = append(want, v)
+
+				// interleave extractions to exercise partially consolidated states
+				if i%7 == 0 {
+					sort.Ints(want)
+					got, err := pq.Extract()
+					if err != nil {
+						t.Fatalf("unexpected error: %v", err)
+					}
+					if got != want[0] {
+						t.Fatalf("extract: expected %d, got %d", want[0], got)
+					}
+					want = want[1:]
+				}
+			}
+
+			sort.Ints(want)
+
+			if pq.Size() != len(want) {
+				t.Fatalf("expected size %d, got %d", len(want), pq.Size())
+			}
+
+			for _, w := range want {
+				if top, _ := pq.Peek(); top != w {
+					t.Fatalf("peek: expected %d, got %d", w, top)
+				}
+				if got, _ := pq.Extract(); got != w {
+					t.Fatalf("extract: expected %d, got %d", w, got)
+				}
+			}
+
+			if !pq.IsEmpty() {
+				t.Error("priority queue should be empty")
+			}
+
+			if _, err := pq.Extract(); err == nil {
+				t.Error("expected error when extracting from empty priority queue")
+			}
+		})
+	}
+}
+
+// heapHandle is a handle to an element of a meldableHeap.
+type heapHandle interface {
+	Value() int
+}
+
+// meldableHeap is a priority queue with handles, so that PairingHeap and
+// FibonacciHeap can share their tests.
+type meldableHeap interface {
+	ds.PriorityQueue[int]
+	InsertNode(v int) heapHandle
+	DecreaseKey(node heapHandle, v int) error
+	Meld(other meldableHeap)
+}
+
+// pairingHeap adapts PairingHeap to meldableHeap.
+type pairingHeap struct{ *ds.PairingHeap[int] }
+
+func (h pairingHeap) InsertNode(v int) heapHandle {
+	return h.PairingHeap.InsertNode(v)
+}
+
+func (h pairingHeap) DecreaseKey(node heapHandle, v int) error {
+	return h.PairingHeap.DecreaseKey(node.(*ds.PairingHeapNode[int]), v)
+}
+
+func (h pairingHeap) Meld(other meldableHeap) {
+	h.PairingHeap.Meld(other.(pairingHeap).PairingHeap)
+}
+
+// fibonacciHeap adapts FibonacciHeap to meldableHeap.
+type fibonacciHeap struct{ *ds.FibonacciHeap[int] }
+
+func (h fibonacciHeap) InsertNode(v int) heapHandle {
+	return h.FibonacciHeap.InsertNode(v)
+}
+
+func (h fibonacciHeap) DecreaseKey(node heapHandle, v int) error {
+	return h.FibonacciHeap.DecreaseKey(node.(*ds.FibonacciHeapNode[int]), v)
+}
+
+func (h fibonacciHeap) Meld(other meldableHeap) {
+	h.FibonacciHeap.Meld(other.(fibonacciHeap).FibonacciHeap)
+}
+
+// meldableHeaps returns a constructor for every meldableHeap.
+func meldableHeaps() map[string]func() meldableHeap {
+	return map[string]func() meldableHeap{
+		"PairingHeap":   func() meldableHeap { return pairingHeap{ds.NewPairingHeap[int](compareInt)} },
+		"FibonacciHeap": func() meldableHeap { return fibonacciHeap{ds.NewFibonacciHeap[int](compareInt)} },
+	}
+}
+
+func TestMeldableHeapMeld(t *testing.T) {
+	for name, newHeap := range meldableHeaps() {
+		t.Run(name, func(t *testing.T) {
+			a, b := newHeap(), newHeap()
+
+			for i := 0; i < 10; i++ {
+				a.Insert(2 * i)
+				b.Insert(2*i + 1)
+			}
+
+			a.Meld(b)
+
+			if a.Size() != 20 || !b.IsEmpty() {
+				t.Fatalf("meld: expected sizes 20 and 0, got %d and %d", a.Size(), b.Size())
+			}
+
+			for want := 0; want < 20; want++ {
+				if got, _ := a.Extract(); got != want {
+					t.Fatalf("extract: expected %d, got %d", want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestMeldableHeapDecreaseKey(t *testing.T) {
+	for name, newHeap := range meldableHeaps() {
+		t.Run(name, func(t *testing.T) {
+			r := rand.New(rand.NewSource(7))
+			h := newHeap()
+			nodes := []heapHandle{}
+
+			for _, v := range r.Perm(200) {
+				nodes = append(nodes, h.InsertNode(1000+v))
+			}
+
+			// extracting the minimum builds multi level trees
+			if got, _ := h.Extract(); got != 1000 {
+				t.Fatalf("extract: expected 1000, got %d", got)
+			}
+
+			want := []int{}
+			for i, node := range nodes {
+				if node.Value() == 1000 {
+					continue
+				}
+
+				newValue := node.Value()
+				if i%2 == 0 {
+					newValue = r.Intn(1000)
+				}
+
+				if err := h.DecreaseKey(node, newValue); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				want = append(want, newValue)
+			}
+
+			if err := h.DecreaseKey(nodes[1], 1<<30); err == nil {
+				t.Error("expected error when increasing a key")
+			}
+
+			sort.Ints(want)
+
+			for _, w := range want {
+				if got, _ := h.Extract(); got != w {
+					t.Fatalf("extract: expected %d, got %d", w, got)
+				}
+			}
+		})
+	}
+}
+
+func TestMeldableHeapStaleHandles(t *testing.T) {
+	for name, newHeap := range meldableHeaps() {
+		t.Run(name, func(t *testing.T) {
+			a, b := newHeap(), newHeap()
+
+			first := a.InsertNode(1)
+			a.Extract()
+
+			// the heap is empty, so the extracted node has nothing to refer to
+			if err := a.DecreaseKey(first, 0); err == nil {
+				t.Fatal("expected an error for an extracted node of an empty heap")
+			}
+
+			second := a.InsertNode(5)
+			a.InsertNode(7)
+			extracted := a.InsertNode(2)
+			a.Extract()
+
+			if err := a.DecreaseKey(extracted, 0); err == nil {
+				t.Fatal("expected an error for an extracted node")
+			}
+
+			foreign := b.InsertNode(6)
+
+			if err := a.DecreaseKey(foreign, 0); err == nil {
+				t.Fatal("expected an error for a node of another heap")
+			}
+
+			// melded handles move to the new heap
+			a.Meld(b)
+			later := b.InsertNode(9)
+
+			if err := a.DecreaseKey(foreign, 3); err != nil {
+				t.Fatalf("unexpected error for a melded node: %v", err)
+			}
+
+			if err := b.DecreaseKey(foreign, 2); err == nil {
+				t.Fatal("expected an error for a node melded into another heap")
+			}
+
+			if err := a.DecreaseKey(later, 0); err == nil {
+				t.Fatal("expected an error for a node of another heap")
+			}
+
+			if err := a.DecreaseKey(second, 4); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for _, want := range []int{3, 4, 7} {
+				if got, _ := a.Extract(); got != want {
+					t.Fatalf("extract: expected %d, got %d", want, got)
+				}
+			}
+		})
+	}
+}
+
+func benchmarkPriorityQueue(b *testing.B, newQueue func() ds.PriorityQueue[int]) {
+	values := rand.New(rand.NewSource(5)).Perm(10000)
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		pq := newQueue()
+
+		for _, v := range values {
+			pq.Insert(v)
+		}
+
+		for !pq.IsEmpty() {
+			pq.Extract()
+		}
+	}
+}
+
+func BenchmarkHeapInsertExtract(b *testing.B) {
+	benchmarkPriorityQueue(b, func() ds.PriorityQueue[int] { return ds.NewMinHeap[int](0, compareInt) })
+}
+
+func BenchmarkPairingHeapInsertExtract(b *testing.B) {
+	benchmarkPriorityQueue(b, func() ds.PriorityQueue[int] { return ds.NewPairingHeap[int](compareInt) })
+}
+
+func BenchmarkFibonacciHeapInsertExtract(b *testing.B) {
+	benchmarkPriorityQueue(b, func() ds.PriorityQueue[int] { return ds.NewFibonacciHeap[int](compareInt) })
+}
+
+const meldShards = 64
+const meldShardSize = 256
+
+func BenchmarkHeapMerge(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		merged := ds.NewMinHeap[int](0, compareInt)
+
+		for s := 0; s < meldShards; s++ {
+			shard := ds.NewMinHeap[int](0, compareInt)
+			for v := 0; v < meldShardSize; v++ {
+				shard.Insert(v)
+			}
+
+			// a binary heap can only be merged by moving every element
+			for !shard.IsEmpty() {
+				v, _ := shard.Extract()
+				merged.Insert(v)
+			}
+		}
+	}
+}
+
+func BenchmarkPairingHeapMeld(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		merged := ds.NewPairingHeap[int](compareInt)
+
+		for s := 0; s < meldShards; s++ {
+			shard := ds.NewPairingHeap[int](compareInt)
+			for v := 0; v < meldShardSize; v++ {
+				shard.Insert(v)
+			}
+			merged.Meld(shard)
+		}
+	}
+}
+
+func BenchmarkFibonacciHeapMeld(b *testing.B) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		merged := ds.NewFibonacciHeap[int](compareInt)
+
+		for s := 0; s < meldShards; s++ {
+			shard := ds.NewFibonacciHeap[int](compareInt)
+			for v := 0; v < meldShardSize; v++ {
+				shard.Insert(v)
+			}
+			merged.Meld(shard)
+		}
+	}
+}
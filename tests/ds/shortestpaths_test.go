@@ -0,0 +1,214 @@
+package ds_test
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/welschma/godsa/ds"
+)
+
+// newRandomGraph builds a random graph with v vertices and e calls to AddEdge using
+// weights in [minWeight, maxWeight].
+func newRandomGraph(r *rand.Rand, v, e int, directed bool, minWeight, maxWeight int) *ds.Graph {
+	g := ds.NewGraph(v, directed)
+
+	for i := 0; i < e; i++ {
+		g.AddEdge(r.Intn(v), r.Intn(v), minWeight+r.Intn(maxWeight-minWeight+1))
+	}
+
+	return g
+}
+
+// pathWeight returns the weight of the given path using the lightest edge between
+// consecutive vertices, or -1 if an edge is missing.
+func pathWeight(weights map[[2]int]int, path []int) int {
+	total := 0
+
+	for i := 0; i+1 < len(path); i++ {
+		w, ok := weights[[2]int{path[i], path[i+1]}]
+		if !ok {
+			return -1
+		}
+		total += w
+	}
+
+	return total
+}
+
+// edgeWeights returns the lightest weight for every pair of adjacent vertices, as
+// read back from the output of Graph.Write.
+func edgeWeights(g *ds.Graph) map[[2]int]int {
+	weights := map[[2]int]int{}
+
+	for _, e := range graphEdges(g) {
+		key := [2]int{e[0], e[1]}
+		if w, ok := weights[key]; !ok || e[2] < w {
+			weights[key] = e[2]
+		}
+	}
+
+	return weights
+}
+
+func TestDijkstra(t *testing.T) {
+	g := newTestGraph(6, true, [][3]int{
+		{0, 1, 7}, {0, 2, 9}, {0, 5, 14}, {1, 2, 10}, {1, 3, 15},
+		{2, 3, 11}, {2, 5, 2}, {3, 4, 6}, {5, 4, 9},
+	})
+
+	sp, err := ds.Dijkstra(g, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantDist := []int{0, 7, 9, 20, 20, 11}
+	for v, want := range wantDist {
+		if got := sp.DistTo(v); got != want {
+			t.Errorf("dist to %d: want %d, got %d", v, want, got)
+		}
+	}
+
+	if got := sp.PathTo(4); !reflect.DeepEqual(got, []int{0, 2, 5, 4}) {
+		t.Errorf("path to 4: want [0 2 5 4], got %v", got)
+	}
+
+	if sp.Source() != 0 || sp.HasNegativeCycle() {
+		t.Error("unexpected source or negative cycle")
+	}
+}
+
+func TestDijkstraErrors(t *testing.T) {
+	g := newTestGraph(3, true, [][3]int{{0, 1, 1}, {1, 2, -1}})
+
+	if _, err := ds.Dijkstra(g, 0); err == nil {
+		t.Error("expected error for negative edge weight")
+	}
+
+	if _, err := ds.Dijkstra(g, -1); err == nil {
+		t.Error("expected error for source out of bounds")
+	}
+}
+
+func TestShortestPathsUnreachable(t *testing.T) {
+	g := newTestGraph(3, true, [][3]int{{1, 0, 1}})
+
+	for _, algorithm := range []func(ds.GraphView, int) (*ds.ShortestPaths, error){ds.Dijkstra, ds.BellmanFord} {
+		sp, _ := algorithm(g, 0)
+
+		if sp.HasPathTo(1) || sp.DistTo(1) != ds.INFINITE_DISTANCE || sp.PathTo(1) != nil {
+			t.Error("vertex 1 should not be reachable from 0")
+		}
+
+		if got := sp.PathTo(0); !reflect.DeepEqual(got, []int{0}) {
+			t.Errorf("path to source: want [0], got %v", got)
+		}
+	}
+}
+
+func TestShortestPathsOverflow(t *testing.T) {
+	// the path 0 -> 1 -> 2 is longer than the largest int
+	g := newTestGraph(4, true, [][3]int{{0, 1, math.MaxInt - 1}, {1, 2, 10}, {0, 3, math.MaxInt - 10}, {3, 2, 5}})
+
+	for _, algorithm := range []func(ds.GraphView, int) (*ds.ShortestPaths, error){ds.Dijkstra, ds.BellmanFord} {
+		sp, err := algorithm(g, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if sp.DistTo(1) != math.MaxInt-1 || sp.DistTo(2) != math.MaxInt-5 {
+			t.Fatalf("expected distances %d and %d, got %d and %d", math.MaxInt-1, math.MaxInt-5, sp.DistTo(1), sp.DistTo(2))
+		}
+
+		if got := sp.PathTo(2); !reflect.DeepEqual(got, []int{0, 3, 2}) {
+			t.Fatalf("expected path [0 3 2], got %v", got)
+		}
+
+		sp, _ = algorithm(newTestGraph(3, true, [][3]int{{0, 1, math.MaxInt - 1}, {1, 2, 10}}), 0)
+
+		if sp.HasPathTo(2) || sp.DistTo(2) != ds.INFINITE_DISTANCE {
+			t.Fatalf("expected vertex 2 to be unreachable, got distance %d", sp.DistTo(2))
+		}
+	}
+}
+
+func TestBellmanFordNegativeWeights(t *testing.T) {
+	g := newTestGraph(5, true, [][3]int{
+		{0, 1, 4}, {0, 2, 5}, {1, 3, -3}, {2, 1, -2}, {3, 4, 2}, {2, 4, 1},
+	})
+
+	sp, err := ds.BellmanFord(g, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	wantDist := []int{0, 3, 5, 0, 2}
+	for v, want := range wantDist {
+		if got := sp.DistTo(v); got != want {
+			t.Errorf("dist to %d: want %d, got %d", v, want, got)
+		}
+	}
+
+	if got := sp.PathTo(4); !reflect.DeepEqual(got, []int{0, 2, 1, 3, 4}) {
+		t.Errorf("path to 4: want [0 2 1 3 4], got %v", got)
+	}
+}
+
+func TestBellmanFordNegativeCycle(t *testing.T) {
+	// 1 -> 2 -> 3 -> 1 has weight -1, vertex 4 is unreachable and on another cycle
+	g := newTestGraph(6, true, [][3]int{
+		{0, 1, 1}, {1, 2, 2}, {2, 3, -4}, {3, 1, 1}, {3, 5, 1}, {4, 4, -1},
+	})
+	weights := edgeWeights(g)
+
+	sp, err := ds.BellmanFord(g, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !sp.HasNegativeCycle() {
+		t.Fatal("expected a negative cycle")
+	}
+
+	cycle := sp.NegativeCycle()
+	if len(cycle) != 4 || cycle[0] != cycle[3] {
+		t.Fatalf("expected a closed cycle of three vertices, got %v", cycle)
+	}
+
+	if w := pathWeight(weights, cycle); w >= 0 {
+		t.Errorf("cycle %v should have negative weight, got %d", cycle, w)
+	}
+
+	if sp.PathTo(5) != nil {
+		t.Error("paths are undefined in the presence of a negative cycle")
+	}
+
+	// the cycle on vertex 4 is not reachable from 0
+	sp, _ = ds.BellmanFord(newTestGraph(5, true, [][3]int{{0, 1, 1}, {4, 4, -1}}), 0)
+	if sp.HasNegativeCycle() {
+		t.Error("unreachable negative cycle should not be reported")
+	}
+}
+
+func TestDijkstraMatchesBellmanFord(t *testing.T) {
+	r := rand.New(rand.NewSource(10))
+
+	for i := 0; i < 20; i++ {
+		g := newRandomGraph(r, 30, 120, i%2 == 0, 0, 20)
+		weights := edgeWeights(g)
+
+		dijkstra, _ := ds.Dijkstra(g, 0)
+		bellmanFord, _ := ds.BellmanFord(g, 0)
+
+		for v := 0; v < g.V(); v++ {
+			if dijkstra.DistTo(v) != bellmanFord.DistTo(v) {
+				t.Fatalf("dist to %d: dijkstra %d, bellman-ford %d", v, dijkstra.DistTo(v), bellmanFord.DistTo(v))
+			}
+
+			if dijkstra.HasPathTo(v) && pathWeight(weights, dijkstra.PathTo(v)) != dijkstra.DistTo(v) {
+				t.Fatalf("path to %d does not have weight %d", v, dijkstra.DistTo(v))
+			}
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package ds_test
+
+import (
+	"bytes"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/welschma/godsa/ds"
+)
+
+func TestSNAPRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(18))
+
+	for i := 0; i < 100; i++ {
+		directed := r.Intn(2) == 0
+		g := newRandomGraph(r, 1+r.Intn(10), r.Intn(30), directed, 1, 1+r.Intn(2))
+
+		var buffer bytes.Buffer
+		if err := g.WriteSNAP(&buffer); err != nil {
+			t.Fatal(err)
+		}
+
+		read, err := ds.ReadSNAP(&buffer, directed)
+		if err != nil {
+			t.Fatalf("graph %d: %v", i, err)
+		}
+
+		if read.V() != g.V() || read.E() != g.E() || !reflect.DeepEqual(sortedEdges(read), sortedEdges(g)) {
+			t.Fatalf("graph %d: expected %v, got %v", i, sortedEdges(g), sortedEdges(read))
+		}
+	}
+}
+
+func TestReadSNAP(t *testing.T) {
+	var buffer bytes.Buffer
+	newTestGraph(3, true, [][3]int{{0, 2, 1}}).WriteSNAP(&buffer)
+
+	expected := "# Directed graph\n# Nodes: 3 Edges: 1\n# FromNodeId\tToNodeId\n0\t2\n"
+	if buffer.String() != expected {
+		t.Fatalf("expected %q, got %q", expected, buffer.String())
+	}
+
+	// vertices are added as they appear
+	g, err := ds.ReadSNAP(strings.NewReader("# no header\n5 1\n\n1  2 7\n"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	edges := []ds.Edge{{From: 1, To: 2, Weight: 7}, {From: 1, To: 5, Weight: 1}}
+	if g.V() != 6 || g.Directed() || !reflect.DeepEqual(sortedEdges(g), edges) {
+		t.Fatalf("expected 6 vertices and edges %v, got %d and %v", edges, g.V(), sortedEdges(g))
+	}
+
+	for _, input := range []string{"1 2 3 4\n", "1\n", "-1 2\n", "1 x\n", "# Nodes: 999999999999 Edges: 1\n", "0 1048576\n"} {
+		if _, err := ds.ReadSNAP(strings.NewReader("# comment\n"+input), true); err == nil || !strings.HasPrefix(err.Error(), "line 2:") {
+			t.Fatalf("input %q: expected an error on line 2, got %v", input, err)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package ds_test
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/welschma/godsa/ds"
+)
+
+var sccAlgorithms = map[string]func(ds.GraphView) (*ds.StronglyConnectedComponents, error){
+	"Tarjan":   ds.TarjanSCC,
+	"Kosaraju": ds.KosarajuSCC,
+}
+
+func TestStronglyConnectedComponents(t *testing.T) {
+	// tinyDG from Algorithms, 4th edition
+	g := newTestGraph(13, true, [][3]int{
+		{4, 2, 1}, {2, 3, 1}, {3, 2, 1}, {6, 0, 1}, {0, 1, 1}, {2, 0, 1}, {11, 12, 1}, {12, 9, 1}, {9, 10, 1},
+		{9, 11, 1}, {7, 9, 1}, {10, 12, 1}, {11, 4, 1}, {4, 3, 1}, {3, 5, 1}, {6, 8, 1}, {8, 6, 1}, {5, 4, 1},
+		{0, 5, 1}, {6, 4, 1}, {6, 9, 1}, {7, 6, 1},
+	})
+
+	want := [][]int{{7}, {6, 8}, {9, 10, 11, 12}, {0, 2, 3, 4, 5}, {1}}
+
+	for name, algorithm := range sccAlgorithms {
+		scc, err := algorithm(g)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		if scc.Count() != 5 {
+			t.Fatalf("%s: expected 5 components, got %d", name, scc.Count())
+		}
+
+		if got := scc.Components(); !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: components: want %v, got %v", name, want, got)
+		}
+
+		if !scc.StronglyConnected(9, 12) || scc.StronglyConnected(1, 0) || scc.ID(13) != -1 {
+			t.Errorf("%s: strongly connected reports wrong membership", name)
+		}
+	}
+}
+
+func TestStronglyConnectedComponentsUndirected(t *testing.T) {
+	for name, algorithm := range sccAlgorithms {
+		if _, err := algorithm(ds.NewGraph(2, false)); err == nil {
+			t.Errorf("%s: expected error for undirected graph", name)
+		}
+	}
+}
+
+func TestCondensation(t *testing.T) {
+	// {0, 1} -> {2, 3} with two edges, {2, 3} -> {4}
+	g := newTestGraph(5, true, [][3]int{{0, 1, 1}, {1, 0, 1}, {0, 2, 3}, {1, 3, 4}, {2, 3, 1}, {3, 2, 1}, {3, 4, 7}})
+	scc, _ := ds.TarjanSCC(g)
+
+	condensation := scc.Condensation(nil)
+
+	if condensation.V() != 3 || condensation.E() != 2 {
+		t.Fatalf("expected 3 vertices and 2 edges, got %d and %d", condensation.V(), condensation.E())
+	}
+
+	want := []ds.Edge{{From: 0, To: 1, Weight: 7}, {From: 1, To: 2, Weight: 7}}
+	if got := condensation.Edges(); !reflect.DeepEqual(got, want) {
+		t.Errorf("edges: want %v, got %v", want, got)
+	}
+
+	minimum := scc.Condensation(func(a, b int) int {
+		if a < b {
+			return a
+		}
+		return b
+	})
+
+	if got := minimum.Edges()[0].Weight; got != 3 {
+		t.Errorf("expected minimum weight 3, got %d", got)
+	}
+
+	if _, err := ds.TopologicalSort(condensation); err != nil {
+		t.Errorf("condensation should be acyclic: %v", err)
+	}
+}
+
+func TestStronglyConnectedComponentsRandomized(t *testing.T) {
+	r := rand.New(rand.NewSource(14))
+
+	for i := 0; i < 20; i++ {
+		g := newRandomGraph(r, 40, 60, true, 1, 1)
+		tarjan, _ := ds.TarjanSCC(g)
+		kosaraju, _ := ds.KosarajuSCC(g)
+
+		if tarjan.Count() != kosaraju.Count() {
+			t.Fatalf("component counts differ: tarjan %d, kosaraju %d", tarjan.Count(), kosaraju.Count())
+		}
+
+		// mutual reachability defines strong connectivity
+		for x := 0; x < g.V(); x++ {
+			fromX, _ := ds.BreadthFirstSearch(g, x, nil)
+
+			for y := 0; y < g.V(); y++ {
+				fromY, _ := ds.BreadthFirstSearch(g, y, nil)
+				want := fromX.Visited(y) && fromY.Visited(x)
+
+				if tarjan.StronglyConnected(x, y) != want || kosaraju.StronglyConnected(x, y) != want {
+					t.Fatalf("vertices %d and %d: expected strongly connected %v", x, y, want)
+				}
+			}
+		}
+
+		for _, e := range g.Edges() {
+			if tarjan.ID(e.From) > tarjan.ID(e.To) || kosaraju.ID(e.From) > kosaraju.ID(e.To) {
+				t.Fatalf("edge %d -> %d leads to a lower component id", e.From, e.To)
+			}
+		}
+	}
+}
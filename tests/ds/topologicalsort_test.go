@@ -0,0 +1,120 @@
+package ds_test
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/welschma/godsa/ds"
+)
+
+var topologicalSortAlgorithms = map[string]func(ds.GraphView) ([]int, error){
+	"Kahn":          ds.TopologicalSort,
+	"DFS":           ds.TopologicalSortDFS,
+	"Lexicographic": ds.LexicographicTopologicalSort,
+}
+
+// checkTopologicalOrder fails the test if the order does not contain every vertex
+// exactly once or an edge points backwards.
+func checkTopologicalOrder(t *testing.T, g *ds.Graph, order []int) {
+	t.Helper()
+
+	position := map[int]int{}
+	for i, v := range order {
+		position[v] = i
+	}
+
+	if len(order) != g.V() || len(position) != g.V() {
+		t.Fatalf("order %v is not a permutation of the vertices", order)
+	}
+
+	for _, e := range g.Edges() {
+		if position[e.From] >= position[e.To] {
+			t.Fatalf("edge %d -> %d points backwards in %v", e.From, e.To, order)
+		}
+	}
+}
+
+func TestTopologicalSort(t *testing.T) {
+	// build dependencies: 5 -> 2 -> 3 -> 1, 5 -> 0, 4 -> 0, 4 -> 1
+	g := newTestGraph(6, true, [][3]int{{5, 2, 1}, {5, 0, 1}, {4, 0, 1}, {4, 1, 1}, {2, 3, 1}, {3, 1, 1}})
+
+	for name, algorithm := range topologicalSortAlgorithms {
+		order, err := algorithm(g)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+
+		checkTopologicalOrder(t, g, order)
+	}
+
+	order, _ := ds.LexicographicTopologicalSort(g)
+	if want := []int{4, 5, 0, 2, 3, 1}; !reflect.DeepEqual(order, want) {
+		t.Errorf("lexicographic order: want %v, got %v", want, order)
+	}
+}
+
+func TestTopologicalSortErrors(t *testing.T) {
+	cyclic := newTestGraph(4, true, [][3]int{{0, 1, 1}, {1, 2, 1}, {2, 3, 1}, {3, 1, 1}})
+	undirected := newTestGraph(2, false, [][3]int{{0, 1, 1}})
+
+	for name, algorithm := range topologicalSortAlgorithms {
+		if _, err := algorithm(cyclic); err == nil {
+			t.Errorf("%s: expected error for cyclic graph", name)
+		}
+
+		if _, err := algorithm(undirected); err == nil {
+			t.Errorf("%s: expected error for undirected graph", name)
+		}
+	}
+}
+
+func TestFindCycle(t *testing.T) {
+	acyclic := newTestGraph(4, true, [][3]int{{0, 1, 1}, {0, 2, 1}, {1, 3, 1}, {2, 3, 1}})
+
+	if cycle, err := ds.FindCycle(acyclic); err != nil || cycle != nil {
+		t.Errorf("expected no cycle, got %v (%v)", cycle, err)
+	}
+
+	cyclic := newTestGraph(5, true, [][3]int{{0, 1, 1}, {1, 2, 1}, {2, 3, 1}, {3, 1, 1}, {3, 4, 1}})
+	cycle, _ := ds.FindCycle(cyclic)
+
+	if want := []int{1, 2, 3, 1}; !reflect.DeepEqual(cycle, want) {
+		t.Errorf("cycle: want %v, got %v", want, cycle)
+	}
+
+	selfLoop := newTestGraph(2, true, [][3]int{{0, 1, 1}, {1, 1, 1}})
+	if cycle, _ := ds.FindCycle(selfLoop); !reflect.DeepEqual(cycle, []int{1, 1}) {
+		t.Errorf("cycle: want [1 1], got %v", cycle)
+	}
+
+	if _, err := ds.FindCycle(ds.NewGraph(1, false)); err == nil {
+		t.Error("expected error for undirected graph")
+	}
+}
+
+func TestTopologicalSortRandomized(t *testing.T) {
+	r := rand.New(rand.NewSource(13))
+
+	for i := 0; i < 20; i++ {
+		// edges from lower to higher labels of a random permutation form a DAG
+		labels := r.Perm(50)
+		g := ds.NewGraph(50, true)
+
+		for j := 0; j < 200; j++ {
+			x, y := r.Intn(50), r.Intn(50)
+			if labels[x] < labels[y] {
+				g.AddEdge(x, y, 1)
+			}
+		}
+
+		for name, algorithm := range topologicalSortAlgorithms {
+			order, err := algorithm(g)
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", name, err)
+			}
+
+			checkTopologicalOrder(t, g, order)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package ds_test
+
+import (
+	"testing"
+
+	"github.com/welschma/godsa/ds"
+)
+
+func TestUnionFind(t *testing.T) {
+	uf := ds.NewUnionFind(10)
+
+	if uf.Count() != 10 {
+		t.Fatalf("expected 10 sets, got %d", uf.Count())
+	}
+
+	pairs := [][2]int{{4, 3}, {3, 8}, {6, 5}, {9, 4}, {2, 1}, {5, 0}, {7, 2}, {6, 1}}
+	for _, p := range pairs {
+		if !uf.Union(p[0], p[1]) {
+			t.Errorf("union %v: expected sets to be merged", p)
+		}
+	}
+
+	if uf.Union(8, 9) {
+		t.Error("union of connected elements should return false")
+	}
+
+	if uf.Count() != 2 {
+		t.Errorf("expected 2 sets, got %d", uf.Count())
+	}
+
+	if !uf.Connected(0, 7) || !uf.Connected(3, 9) || uf.Connected(0, 9) {
+		t.Error("connected reports wrong membership")
+	}
+
+	if uf.Find(8) != uf.Find(4) {
+		t.Error("elements of the same set should have the same representative")
+	}
+}